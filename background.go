@@ -0,0 +1,185 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const DefaultJobsSpace = "_migrator_jobs"
+
+const defaultJobPollInterval = time.Second
+const defaultJobRequestTimeout = 10 * time.Second
+
+const backgroundJobDone = "done"
+const backgroundJobFailed = "failed"
+
+// backgroundJob is the result of the background job status lua script.
+type backgroundJob struct {
+	_msgpack struct{} `msgpack:",as_array"` //nolint:unused
+	// ID of the job, migration ID and direction
+	ID string
+	// Status is "running", "done" or "failed"
+	Status string
+	// Error raised by the job fiber
+	Error string
+}
+
+// NewBackgroundMigrateFunction starts lua code in a fiber on the server and waits for its completion.
+// Unlike NewGenericMigrateFunction, the migration is not limited by the request timeout of the connection:
+// the job is registered in Options.JobsSpace and its status is polled every Options.JobPollInterval.
+// Failed polls are retried and the fiber is cancelled when the context is done.
+// A job which has already finished successfully is not started again. Started jobs are bound to the instance,
+// so Options.WriteMode must always select the same one.
+func NewBackgroundMigrateFunction(req string) func(context.Context, pool.Pooler, Options) error {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		expr := req
+		if opts.Transactional {
+			expr = wrapLuaTransaction(req)
+		}
+
+		args := newMigrationArgs(ctx, opts)
+
+		jobID := args.ID + "." + args.Direction
+
+		var oppositeID any
+		if args.ID == "" {
+			jobID = fmt.Sprintf("job.%d", time.Now().UnixNano())
+		} else {
+			oppositeID = args.ID + "." + oppositeDirection(args.Direction)
+		}
+
+		if err := startBackgroundJob(ctx, tt, opts, jobID, oppositeID, expr, args); err != nil {
+			return err
+		}
+
+		LoggerFromContext(ctx).InfoContext(ctx, "background job started", "job", jobID)
+
+		return waitBackgroundJob(ctx, tt, opts, jobID)
+	}
+}
+
+// startBackgroundJob starts the job fiber unless the job is still running or has already finished successfully.
+// The finished job of the opposite direction is removed, so the migration can run again after rollback.
+func startBackgroundJob(ctx context.Context, tt pool.Pooler, opts Options, jobID string, oppositeID any, expr string,
+	args MigrationArgs) error {
+	data, err := LuaFs.ReadFile(startBackgroundJobPath)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, jobRequestTimeout(opts))
+	defer cancel()
+
+	req := tarantool.NewEvalRequest(string(data)).Context(reqCtx).Args([]any{opts.JobsSpace, jobID, expr, args,
+		oppositeID})
+
+	_, err = tt.Do(req, opts.WriteMode).Get()
+	if err != nil {
+		return fmt.Errorf("start background job: %w", err)
+	}
+
+	return nil
+}
+
+func waitBackgroundJob(ctx context.Context, tt pool.Pooler, opts Options, jobID string) error {
+	interval := opts.JobPollInterval
+	if interval <= 0 {
+		interval = defaultJobPollInterval
+	}
+
+	for {
+		job, err := backgroundJobStatus(ctx, tt, opts, jobID)
+
+		switch {
+		case ctx.Err() != nil:
+			return cancelBackgroundJob(ctx, tt, opts, jobID, ctx.Err())
+		case errors.Is(err, ErrBackgroundJobNotFound):
+			return err
+		case err != nil:
+			// the job keeps running on the server, a failed poll must not abandon it
+			LoggerFromContext(ctx).WarnContext(ctx, "background job status poll failed", "job", jobID, "error", err)
+			job = &backgroundJob{ID: jobID}
+		}
+
+		switch job.Status {
+		case backgroundJobDone:
+			return nil
+		case backgroundJobFailed:
+			return fmt.Errorf("%w: %s", ErrBackgroundJobFailed, job.Error)
+		}
+
+		if err = sleepContext(ctx, interval); err != nil {
+			return cancelBackgroundJob(ctx, tt, opts, jobID, err)
+		}
+	}
+}
+
+func backgroundJobStatus(ctx context.Context, tt pool.Pooler, opts Options, jobID string) (*backgroundJob, error) {
+	data, err := LuaFs.ReadFile(backgroundJobStatusPath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, jobRequestTimeout(opts))
+	defer cancel()
+
+	var jobs []*backgroundJob
+
+	req := tarantool.NewEvalRequest(string(data)).Context(reqCtx).Args([]any{opts.JobsSpace, jobID})
+
+	err = tt.Do(req, opts.WriteMode).GetTyped(&jobs)
+	if err != nil {
+		return nil, fmt.Errorf("background job status: %w", err)
+	}
+
+	if len(jobs) == 0 || jobs[0] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBackgroundJobNotFound, jobID)
+	}
+
+	return jobs[0], nil
+}
+
+// cancelBackgroundJob cancels the job fiber after the context is done, cause is returned along with cancel error.
+func cancelBackgroundJob(ctx context.Context, tt pool.Pooler, opts Options, jobID string, cause error) error {
+	cause = fmt.Errorf("background job %q: %w", jobID, cause)
+
+	data, err := LuaFs.ReadFile(cancelBackgroundJobPath)
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("read lua script: %w", err))
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobRequestTimeout(opts))
+	defer cancel()
+
+	req := tarantool.NewEvalRequest(string(data)).Context(reqCtx).Args([]any{jobID})
+
+	_, err = tt.Do(req, opts.WriteMode).Get()
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("cancel background job: %w", err))
+	}
+
+	LoggerFromContext(ctx).WarnContext(ctx, "background job cancelled", "job", jobID)
+
+	return cause
+}
+
+func oppositeDirection(direction string) string {
+	if direction == MigrationDirectionDown {
+		return MigrationDirectionUp
+	}
+
+	return MigrationDirectionDown
+}
+
+func jobRequestTimeout(opts Options) time.Duration {
+	if opts.JobRequestTimeout <= 0 {
+		return defaultJobRequestTimeout
+	}
+
+	return opts.JobRequestTimeout
+}
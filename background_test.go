@@ -0,0 +1,149 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type BackgroundTestSuite struct {
+	suite.Suite
+	ctx  context.Context
+	mock *mocks.PoolerMock
+	doer test_helpers.MockDoer
+	opts Options
+}
+
+func (suite *BackgroundTestSuite) SetupTest() {
+	suite.ctx = contextWithMigrationArgs(contextWithLogger(context.Background(), SilentLogger),
+		&Migration{ID: "migration-1"}, MigrationDirectionUp)
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.opts = DefaultOptions
+	suite.opts.JobPollInterval = time.Millisecond
+}
+
+func (suite *BackgroundTestSuite) args(call int) []any {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[call].Req, calls[call].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec.Args.([]any)
+}
+
+func (suite *BackgroundTestSuite) TestMigrateDone() {
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "running", nil}})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "done", nil}})
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+
+	start := suite.args(0)
+	assert.Equal(suite.T(), []any{DefaultJobsSpace, "migration-1.up", "box.info()"}, start[:3])
+	assert.Equal(suite.T(), "migration-1", start[3].(map[string]any)["id"])
+	assert.Equal(suite.T(), "migration-1.down", start[4])
+	assert.Equal(suite.T(), []any{DefaultJobsSpace, "migration-1.up"}, suite.args(2))
+}
+
+func (suite *BackgroundTestSuite) TestMigrateRetriesStatusPoll() {
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseError(fmt.Errorf("connection lost"))
+	suite.doer.AddResponseError(fmt.Errorf("connection lost"))
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "done", nil}})
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+}
+
+func (suite *BackgroundTestSuite) TestMigrateWithoutMigrationID() {
+	suite.doer.AddResponseRaw([]interface{}{"job"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"job", "done", nil}})
+
+	err := NewBackgroundMigrateFunction("box.info()")(contextWithLogger(context.Background(), SilentLogger),
+		suite.mock, suite.opts)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), suite.args(0)[4])
+}
+
+func (suite *BackgroundTestSuite) TestMigrateFailed() {
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "failed", "space exists"}})
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.ErrorIs(suite.T(), err, ErrBackgroundJobFailed)
+	assert.Equal(suite.T(), "background job failed: space exists", err.Error())
+}
+
+func (suite *BackgroundTestSuite) TestMigrateNotFound() {
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{nil})
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.Equal(suite.T(), "background job not found: migration-1.up", err.Error())
+}
+
+func (suite *BackgroundTestSuite) TestMigrateStartError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.Equal(suite.T(), "start background job: tarantool error", err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+}
+
+func (suite *BackgroundTestSuite) TestMigrateTransactional() {
+	suite.opts.Transactional = true
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "done", nil}})
+
+	err := NewBackgroundMigrateFunction("box.info()")(suite.ctx, suite.mock, suite.opts)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), wrapLuaTransaction("box.info()"), suite.args(0)[2])
+}
+
+func (suite *BackgroundTestSuite) TestMigrateCancelled() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.opts.JobPollInterval = time.Hour
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "running", nil}})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := NewBackgroundMigrateFunction("box.info()")(ctx, suite.mock, suite.opts)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Equal(suite.T(), `background job "migration-1.up": context canceled`, err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+	assert.Equal(suite.T(), []any{"migration-1.up"}, suite.args(2))
+}
+
+func (suite *BackgroundTestSuite) TestMigrateCancelError() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.opts.JobPollInterval = time.Hour
+	suite.doer.AddResponseRaw([]interface{}{"migration-1.up"})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{"migration-1.up", "running", nil}})
+	suite.doer.AddResponseError(fmt.Errorf("connection lost"))
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := NewBackgroundMigrateFunction("box.info()")(ctx, suite.mock, suite.opts)
+	assert.Equal(suite.T(), "background job \"migration-1.up\": context canceled\n"+
+		"cancel background job: connection lost", err.Error())
+}
+
+func TestBackgroundTestSuite(t *testing.T) {
+	suite.Run(t, new(BackgroundTestSuite))
+}
@@ -0,0 +1,291 @@
+package tarantool_migrator
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	_ "github.com/tarantool/go-tarantool/v3/decimal" // decodes decimal fields of backed up tuples
+	"github.com/tarantool/go-tarantool/v3/pool"
+	_ "github.com/tarantool/go-tarantool/v3/uuid" // decodes uuid fields of backed up tuples
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const DefaultBackupDir = "backups"
+
+// BackupFormat is the format of records in gzip-compressed backup file.
+type BackupFormat string
+
+const (
+	// BackupFormatMsgpack keeps all tarantool types, e.g. datetime, decimal and uuid.
+	BackupFormatMsgpack BackupFormat = "msgpack"
+	// BackupFormatJSONL is human-readable, extension types are not restored.
+	BackupFormatJSONL BackupFormat = "jsonl"
+)
+
+// backupRecord is a single tuple of the backup file.
+type backupRecord struct {
+	Space string `msgpack:"space" json:"space"`
+	Tuple []any  `msgpack:"tuple" json:"tuple"`
+}
+
+// Backup streams tuples of spaces into a new gzip-compressed file in Options.BackupDir and returns its path.
+// Spaces are read with SelectRequest pagination, so no filesystem access to the tarantool host is needed.
+func Backup(ctx context.Context, tt pool.Pooler, opts Options, name string, spaces ...string) (string, error) {
+	format := opts.BackupFormat
+	if format == "" {
+		format = BackupFormatMsgpack
+	}
+
+	if err := os.MkdirAll(opts.BackupDir, 0o750); err != nil {
+		return "", fmt.Errorf("create backup dir: %w", err)
+	}
+
+	file, err := os.CreateTemp(opts.BackupDir, "."+name+".*")
+	if err != nil {
+		return "", fmt.Errorf("create backup file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	err = writeBackup(ctx, tt, opts, file, format, spaces)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close backup file: %w", closeErr)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(opts.BackupDir, fmt.Sprintf("%s.%d.%s.gz", name, time.Now().Unix(), format))
+	if err = os.Rename(file.Name(), path); err != nil {
+		return "", fmt.Errorf("rename backup file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Restore replaces tuples from the backup file into their spaces and returns the number of restored tuples.
+func Restore(ctx context.Context, tt pool.Pooler, opts Options, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open backup file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, fmt.Errorf("read backup file: %w", err)
+	}
+
+	decode := newBackupDecoder(zr, backupFormatFromPath(path))
+	restored := 0
+	batch := make([][]any, 0, defaultBatchSize)
+
+	for {
+		record, err := decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return restored, fmt.Errorf("read backup file: %w", err)
+		}
+
+		batch = append(batch, []any{record.Space, record.Tuple})
+		if len(batch) < defaultBatchSize {
+			continue
+		}
+
+		if err = writeBatch(ctx, tt, opts, batch, restoreRequests); err != nil {
+			return restored, fmt.Errorf("restore: %w", err)
+		}
+
+		restored += len(batch)
+		batch = batch[:0]
+	}
+
+	if err = writeBatch(ctx, tt, opts, batch, restoreRequests); err != nil {
+		return restored, fmt.Errorf("restore: %w", err)
+	}
+
+	return restored + len(batch), nil
+}
+
+// Restore replaces tuples from the backup file taken before migration, see Restore.
+func (m *Migrator) Restore(ctx context.Context, path string) (int, error) {
+	restored, err := Restore(contextWithLogger(ctx, m.logger), m.tt, *m.opts, path)
+	if err != nil {
+		return restored, fmt.Errorf(`restore "%s" error: %w`, path, err)
+	}
+
+	m.logger.InfoContext(ctx, "backup restored", "path", path, "tuples", restored)
+
+	return restored, nil
+}
+
+func (m *Migrator) backup(ctx context.Context, migration *Migration) error {
+	if len(migration.BackupSpaces) == 0 || m.opts.DryRun {
+		return nil
+	}
+
+	path, err := Backup(contextWithLogger(ctx, m.logger.With("id", migration.ID)), m.tt, *m.opts, migration.ID,
+		migration.BackupSpaces...)
+	if err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+
+	m.logger.InfoContext(ctx, "backup created", "id", migration.ID, "path", path)
+
+	return nil
+}
+
+func writeBackup(ctx context.Context, tt pool.Pooler, opts Options, w io.Writer, format BackupFormat,
+	spaces []string) error {
+	zw := gzip.NewWriter(w)
+	encode := newBackupEncoder(zw, format)
+
+	for _, space := range spaces {
+		if err := backupSpace(ctx, tt, opts, space, encode); err != nil {
+			return fmt.Errorf("backup space %q: %w", space, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compress backup: %w", err)
+	}
+
+	return nil
+}
+
+func backupSpace(ctx context.Context, tt pool.Pooler, opts Options, space string,
+	encode func(backupRecord) error) error {
+	data, err := LuaFs.ReadFile(primaryKeyFieldsPath)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	var fields [][]int
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{space})
+
+	err = tt.Do(req, opts.WriteMode).GetTyped(&fields)
+	if err != nil {
+		return fmt.Errorf("primary key: %w", err)
+	}
+
+	batchOpts := BatchOptions{Space: space}
+	if len(fields) > 0 {
+		batchOpts.KeyFields = fields[0]
+	}
+
+	writeTuples := func(_ context.Context, tuples [][]any) ([]tarantool.Request, error) {
+		for _, tuple := range tuples {
+			if err := encode(backupRecord{Space: space, Tuple: tuple}); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	}
+
+	_, err = RunBatches(ctx, tt, opts, batchOpts, writeTuples)
+
+	return err
+}
+
+func restoreRequests(_ context.Context, records [][]any) ([]tarantool.Request, error) {
+	requests := make([]tarantool.Request, 0, len(records))
+	for _, record := range records {
+		requests = append(requests, tarantool.NewReplaceRequest(record[0]).Tuple(record[1]))
+	}
+
+	return requests, nil
+}
+
+func newBackupEncoder(w io.Writer, format BackupFormat) func(backupRecord) error {
+	if format == BackupFormatJSONL {
+		enc := json.NewEncoder(w)
+
+		return func(record backupRecord) error {
+			return enc.Encode(record)
+		}
+	}
+
+	enc := msgpack.NewEncoder(w)
+
+	return func(record backupRecord) error {
+		return enc.Encode(record)
+	}
+}
+
+func newBackupDecoder(r io.Reader, format BackupFormat) func() (backupRecord, error) {
+	if format == BackupFormatJSONL {
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+
+		return func() (backupRecord, error) {
+			var record backupRecord
+			if err := dec.Decode(&record); err != nil {
+				return record, err
+			}
+
+			record.Tuple, _ = normalizeJSONNumbers(record.Tuple).([]any)
+
+			return record, nil
+		}
+	}
+
+	dec := msgpack.NewDecoder(r)
+
+	return func() (backupRecord, error) {
+		var record backupRecord
+		err := dec.Decode(&record)
+
+		return record, err
+	}
+}
+
+func backupFormatFromPath(path string) BackupFormat {
+	if strings.HasSuffix(path, "."+string(BackupFormatJSONL)+".gz") {
+		return BackupFormatJSONL
+	}
+
+	return BackupFormatMsgpack
+}
+
+// normalizeJSONNumbers converts json.Number into integers where possible, tarantool rejects floats in integer fields.
+func normalizeJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case []any:
+		for i := range v {
+			v[i] = normalizeJSONNumbers(v[i])
+		}
+
+		return v
+	case map[string]any:
+		for key := range v {
+			v[key] = normalizeJSONNumbers(v[key])
+		}
+
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,176 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-iproto"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/decimal"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type BackupTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	opts     Options
+	testable *Migrator
+}
+
+func (suite *BackupTestSuite) SetupTest() {
+	suite.ctx = contextWithLogger(context.Background(), SilentLogger)
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+
+	suite.opts = DefaultOptions
+	suite.opts.BackupDir = suite.T().TempDir()
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), BackupSpaces: []string{"users"}},
+	}, WithLogger(SilentLogger), WithOptions(&suite.opts))
+}
+
+func (suite *BackupTestSuite) backupFiles() []string {
+	files, err := os.ReadDir(suite.opts.BackupDir)
+	assert.NoError(suite.T(), err)
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	return names
+}
+
+func (suite *BackupTestSuite) restore(path string) []RecordedRequest {
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+
+	before := len(suite.mock.DoCalls())
+
+	restored, err := suite.testable.Restore(suite.ctx, path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, restored)
+
+	calls := suite.mock.DoCalls()[before:]
+	requests := make([]RecordedRequest, 0, len(calls))
+
+	for _, call := range calls {
+		rec, err := describeRequest(call.Req, call.Mode)
+		assert.NoError(suite.T(), err)
+
+		requests = append(requests, rec)
+	}
+
+	return requests
+}
+
+func (suite *BackupTestSuite) TestMigrateCreatesBackup() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{0}})
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}, {2, "b"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 6)
+
+	files := suite.backupFiles()
+	assert.Len(suite.T(), files, 1)
+	assert.True(suite.T(), strings.HasPrefix(files[0], "migration-1."))
+	assert.True(suite.T(), strings.HasSuffix(files[0], ".msgpack.gz"))
+
+	requests := suite.restore(filepath.Join(suite.opts.BackupDir, files[0]))
+	assert.Len(suite.T(), requests, 2)
+	assert.Equal(suite.T(), "IPROTO_REPLACE", requests[0].Type)
+	assert.Equal(suite.T(), "users", requests[0].Space)
+	assert.Equal(suite.T(), []any{int8(2), "b"}, requests[1].Tuple)
+}
+
+func (suite *BackupTestSuite) TestBackupJSONL() {
+	suite.opts.BackupFormat = BackupFormatJSONL
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{0}})
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}, {2.5, "b"}})
+
+	path, err := Backup(suite.ctx, suite.mock, suite.opts, "manual", "users")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasSuffix(path, ".jsonl.gz"))
+
+	requests := suite.restore(path)
+	assert.Equal(suite.T(), []any{int64(1), "a"}, requests[0].Tuple)
+	assert.Equal(suite.T(), []any{2.5, "b"}, requests[1].Tuple)
+}
+
+func (suite *BackupTestSuite) TestBackupMsgpackExtensions() {
+	id := uuid.MustParse("c8f0fa1f-da29-438c-a040-393f1126ad39")
+	amount := decimal.MustNewDecimal("12.50")
+
+	suite.doer.AddResponseRaw([]interface{}{[]interface{}{0}})
+	suite.doer.AddResponseRaw([][]interface{}{{1, amount, id}, {2, amount, id}})
+
+	path, err := Backup(suite.ctx, suite.mock, suite.opts, "manual", "users")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasSuffix(path, ".msgpack.gz"))
+
+	requests := suite.restore(path)
+	assert.Equal(suite.T(), []any{int8(1), "<decimal.Decimal>", "<uuid.UUID>"}, requests[0].Tuple)
+
+	calls := suite.mock.DoCalls()
+	body, err := decodeRequestBody(calls[len(calls)-1].Req)
+	assert.NoError(suite.T(), err)
+	tuple, _ := body[iproto.IPROTO_TUPLE].([]any)
+	assert.Len(suite.T(), tuple, 3)
+	assert.Equal(suite.T(), "12.5", tuple[1].(decimal.Decimal).String())
+	assert.Equal(suite.T(), id, tuple[2])
+}
+
+func (suite *BackupTestSuite) TestMigrateBackupError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseError(fmt.Errorf("space \"users\" does not exist"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-1" error: backup: backup space "users": `+
+		`primary key: space "users" does not exist`, err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+	assert.Empty(suite.T(), suite.backupFiles())
+}
+
+func (suite *BackupTestSuite) TestMigrateDryRun() {
+	suite.opts.DryRun = true
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.backupFiles())
+}
+
+func (suite *BackupTestSuite) TestRestoreMissingFile() {
+	_, err := suite.testable.Restore(suite.ctx, filepath.Join(suite.opts.BackupDir, "missing.msgpack.gz"))
+	assert.ErrorIs(suite.T(), err, os.ErrNotExist)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
@@ -0,0 +1,99 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const checkpointKeyPrefix = "checkpoint."
+
+// MigrationCheckpoint is the progress of a resumable data migration, stored in Options.StateSpace after every batch.
+type MigrationCheckpoint struct {
+	// ID of the migration
+	ID string `msgpack:"id" json:"id"`
+	// Direction is MigrationDirectionUp or MigrationDirectionDown
+	Direction string `msgpack:"direction" json:"direction"`
+	// Space iterated by RunBatches
+	Space string `msgpack:"space" json:"space"`
+	// Index iterated by RunBatches, "0" for the primary index
+	Index string `msgpack:"index" json:"index"`
+	// Last is the last processed tuple
+	Last []any `msgpack:"last" json:"last"`
+	// Processed is the number of processed tuples
+	Processed int `msgpack:"processed" json:"processed"`
+}
+
+// batchCheckpoint loads and saves checkpoints of RunBatches running inside the migrator.
+// Checkpoints are keyed by the space and the index too, so a migration can run several resumable loops.
+type batchCheckpoint struct {
+	tt      pool.Pooler
+	opts    Options
+	key     string
+	id      string
+	dir     string
+	space   string
+	index   string
+	enabled bool
+}
+
+func newBatchCheckpoint(ctx context.Context, tt pool.Pooler, opts Options, batchOpts BatchOptions) *batchCheckpoint {
+	args := newMigrationArgs(ctx, opts)
+
+	index := "0"
+	if batchOpts.Index != nil {
+		index = fmt.Sprint(batchOpts.Index)
+	}
+
+	return &batchCheckpoint{
+		tt:      tt,
+		opts:    opts,
+		key:     checkpointKeyPrefix + args.ID + "." + args.Direction + "." + batchOpts.Space + "." + index,
+		id:      args.ID,
+		dir:     args.Direction,
+		space:   batchOpts.Space,
+		index:   index,
+		enabled: batchOpts.Resumable && args.ID != "",
+	}
+}
+
+func (c *batchCheckpoint) load(ctx context.Context) (*MigrationCheckpoint, error) {
+	if !c.enabled {
+		return nil, nil
+	}
+
+	checkpoint, err := getMigratorState[MigrationCheckpoint](ctx, c.tt, c.opts, c.opts.WriteMode, c.key)
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (c *batchCheckpoint) save(ctx context.Context, last []any, processed int) error {
+	if !c.enabled {
+		return nil
+	}
+
+	checkpoint := MigrationCheckpoint{
+		ID: c.id, Direction: c.dir, Space: c.space, Index: c.index, Last: last, Processed: processed,
+	}
+	if err := setMigratorState(ctx, c.tt, c.opts, c.key, checkpoint, false); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+func (c *batchCheckpoint) clear(ctx context.Context) error {
+	if !c.enabled {
+		return nil
+	}
+
+	if err := setMigratorState(ctx, c.tt, c.opts, c.key, nil, false); err != nil {
+		return fmt.Errorf("clear checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const defaultBatchSize = 1000
+
+// BatchOptions define how a data migration iterates over the space.
+type BatchOptions struct {
+	// Space to iterate
+	Space string
+	// Index to iterate, the primary index when nil
+	Index any
+	// BatchSize is the number of tuples selected at once, 1000 by default
+	BatchSize uint32
+	// KeyFields are tuple fields of the index key. When set, the next batch is selected with IterGt
+	// from the key of the last tuple. Otherwise, the last tuple is passed to SelectRequest.After (Tarantool 2.11+).
+	KeyFields []int
+	// Throttle is the pause between batches
+	Throttle time.Duration
+	// Resumable stores MigrationCheckpoint after every batch and continues from it after interruption.
+	// Works inside the migrator only, batches must be idempotent as writes and checkpoints are not atomic.
+	Resumable bool
+}
+
+// BatchFunc transforms a batch of tuples into write requests, e.g. ReplaceRequest or UpdateRequest.
+// Requests are sent in Options.WriteMode, all of them must succeed before the next batch is selected.
+type BatchFunc func(ctx context.Context, tuples [][]any) ([]tarantool.Request, error)
+
+// NewBatchMigrateFunction creates migrate function which iterates the space in batches
+// and writes back requests returned by fn. Progress is logged with the migrator logger.
+func NewBatchMigrateFunction(batchOpts BatchOptions, fn BatchFunc) MigrateFunc {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		_, err := RunBatches(ctx, tt, opts, batchOpts, fn)
+
+		return err
+	}
+}
+
+// RunBatches iterates the space in batches until it is exhausted or the context is done.
+// It returns the number of processed tuples.
+func RunBatches(ctx context.Context, tt pool.Pooler, opts Options, batchOpts BatchOptions,
+	fn BatchFunc) (int, error) {
+	logger := LoggerFromContext(ctx).With("space", batchOpts.Space)
+
+	size := batchOpts.BatchSize
+	if size == 0 {
+		size = defaultBatchSize
+	}
+
+	checkpoint := newBatchCheckpoint(ctx, tt, opts, batchOpts)
+
+	var last []any
+
+	processed := 0
+	startedAt := time.Now()
+
+	saved, err := checkpoint.load(ctx)
+	if err != nil {
+		return processed, err
+	}
+
+	if saved != nil {
+		last, processed = saved.Last, saved.Processed
+		logger.InfoContext(ctx, "resuming from checkpoint", "processed", processed)
+	}
+
+	for batch := 1; ; batch++ {
+		if err = ctx.Err(); err != nil {
+			return processed, fmt.Errorf("batch %d: %w", batch, err)
+		}
+
+		var tuples [][]any
+
+		err = tt.Do(newBatchSelectRequest(ctx, batchOpts, size, last), opts.WriteMode).GetTyped(&tuples)
+		if err != nil {
+			return processed, fmt.Errorf("batch %d: select: %w", batch, err)
+		}
+
+		if len(tuples) == 0 {
+			break
+		}
+
+		if err = writeBatch(ctx, tt, opts, tuples, fn); err != nil {
+			return processed, fmt.Errorf("batch %d: %w", batch, err)
+		}
+
+		processed += len(tuples)
+		last = tuples[len(tuples)-1]
+
+		if err = checkpoint.save(ctx, last, processed); err != nil {
+			return processed, fmt.Errorf("batch %d: %w", batch, err)
+		}
+
+		logger.InfoContext(ctx, "batch processed", "batch", batch, "processed", processed,
+			"duration_ms", formatDurationToMs(time.Since(startedAt)))
+
+		if len(tuples) < int(size) {
+			break
+		}
+
+		if err = sleepContext(ctx, batchOpts.Throttle); err != nil {
+			return processed, fmt.Errorf("batch %d: %w", batch+1, err)
+		}
+	}
+
+	return processed, checkpoint.clear(ctx)
+}
+
+func newBatchSelectRequest(ctx context.Context, batchOpts BatchOptions, size uint32, last []any) tarantool.Request {
+	req := tarantool.NewSelectRequest(batchOpts.Space).Context(ctx).Limit(size).Key([]any{})
+	if batchOpts.Index != nil {
+		req = req.Index(batchOpts.Index)
+	}
+
+	if last == nil {
+		return req.Iterator(tarantool.IterAll)
+	}
+
+	if len(batchOpts.KeyFields) == 0 {
+		return req.Iterator(tarantool.IterGt).After(last)
+	}
+
+	return req.Iterator(tarantool.IterGt).Key(tupleKey(last, batchOpts.KeyFields))
+}
+
+// tupleKey extracts key fields from the tuple.
+func tupleKey(tuple []any, fields []int) []any {
+	key := make([]any, 0, len(fields))
+	for _, field := range fields {
+		if field < len(tuple) {
+			key = append(key, tuple[field])
+		}
+	}
+
+	return key
+}
+
+func writeBatch(ctx context.Context, tt pool.Pooler, opts Options, tuples [][]any, fn BatchFunc) error {
+	requests, err := fn(ctx, tuples)
+	if err != nil {
+		return fmt.Errorf("transform: %w", err)
+	}
+
+	futures := make([]tarantool.Future, len(requests))
+	for i, req := range requests {
+		futures[i] = tt.Do(req, opts.WriteMode)
+	}
+
+	for i, future := range futures {
+		if _, err = future.Get(); err != nil {
+			return fmt.Errorf("write request %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
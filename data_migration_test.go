@@ -0,0 +1,253 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-iproto"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type DataMigrationTestSuite struct {
+	suite.Suite
+	ctx  context.Context
+	mock *mocks.PoolerMock
+	doer test_helpers.MockDoer
+	opts BatchOptions
+}
+
+func (suite *DataMigrationTestSuite) SetupTest() {
+	suite.ctx = contextWithLogger(context.Background(), SilentLogger)
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.opts = BatchOptions{Space: "users", BatchSize: 2}
+}
+
+func (suite *DataMigrationTestSuite) replaceAll(_ context.Context, tuples [][]any) ([]tarantool.Request, error) {
+	requests := make([]tarantool.Request, 0, len(tuples))
+	for _, tuple := range tuples {
+		requests = append(requests, tarantool.NewReplaceRequest("users").Tuple(append(tuple, "new")))
+	}
+
+	return requests, nil
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatches() {
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}, {2, "b"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{{3, "c"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	processed, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, processed)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 5)
+
+	first := calls[0].Req.(tarantool.SelectRequest)
+	assert.Equal(suite.T(), iproto.IPROTO_SELECT, first.Type())
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+
+	rec, err := describeRequest(calls[1].Req, calls[1].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "IPROTO_REPLACE", rec.Type)
+	assert.Equal(suite.T(), []any{int8(1), "a", "new"}, rec.Tuple)
+
+	rec, err = describeRequest(calls[3].Req, calls[3].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "IPROTO_SELECT", rec.Type)
+	assert.Equal(suite.T(), []any{}, rec.Key)
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesWithKeyFields() {
+	suite.opts.KeyFields = []int{0}
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}, {2, "b"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	processed, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, processed)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 4)
+
+	rec, err := describeRequest(calls[3].Req, calls[3].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{int8(2)}, rec.Key)
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesTransformError() {
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}})
+
+	processed, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts,
+		func(ctx context.Context, tuples [][]any) ([]tarantool.Request, error) {
+			return nil, fmt.Errorf("wrong tuple")
+		})
+	assert.Equal(suite.T(), 0, processed)
+	assert.Equal(suite.T(), "batch 1: transform: wrong tuple", err.Error())
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesWriteError() {
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	processed, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.Equal(suite.T(), 0, processed)
+	assert.Equal(suite.T(), "batch 1: write request 1: tarantool error", err.Error())
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesSelectError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	_, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.Equal(suite.T(), "batch 1: select: tarantool error", err.Error())
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesContextCanceled() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	processed, err := RunBatches(ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.Equal(suite.T(), 0, processed)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesResumesFromCheckpoint() {
+	ctx := contextWithMigrationArgs(suite.ctx, &Migration{ID: "migration-1"}, MigrationDirectionUp)
+	suite.opts.Resumable = true
+	suite.opts.KeyFields = []int{0}
+	suite.doer.AddResponseRaw([]interface{}{map[string]any{
+		"id": "migration-1", "direction": "up", "last": []any{2, "b"}, "processed": 2,
+	}})
+	suite.doer.AddResponseRaw([][]interface{}{{3, "c"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	processed, err := RunBatches(ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, processed)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 5)
+
+	rec, err := describeRequest(calls[0].Req, calls[0].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, "checkpoint.migration-1.up.users.0"}, rec.Args)
+
+	rec, err = describeRequest(calls[1].Req, calls[1].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{int8(2)}, rec.Key)
+
+	rec, err = describeRequest(calls[3].Req, calls[3].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, "checkpoint.migration-1.up.users.0", map[string]any{
+		"id": "migration-1", "direction": "up", "space": "users", "index": "0",
+		"last": []any{int8(3), "c"}, "processed": int8(3),
+	}, false}, rec.Args)
+
+	rec, err = describeRequest(calls[4].Req, calls[4].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, "checkpoint.migration-1.up.users.0", nil, false}, rec.Args)
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesSeveralResumableLoops() {
+	ctx := contextWithMigrationArgs(suite.ctx, &Migration{ID: "migration-1"}, MigrationDirectionUp)
+	suite.opts.Resumable = true
+	suite.opts.KeyFields = []int{0}
+	suite.doer.AddResponseRaw([]interface{}{nil})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([]interface{}{map[string]any{
+		"id": "migration-1", "direction": "up", "space": "orders", "index": "by_date",
+		"last": []any{"2024-10-08", 7}, "processed": 5,
+	}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	processed, err := RunBatches(ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, processed)
+
+	orders := BatchOptions{Space: "orders", Index: "by_date", KeyFields: []int{1, 0}, Resumable: true}
+	processed, err = RunBatches(ctx, suite.mock, DefaultOptions, orders, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, processed)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 6)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, "checkpoint.migration-1.up.users.0"}, suite.args(0))
+	assert.Equal(suite.T(), []any{DefaultStateSpace, "checkpoint.migration-1.up.orders.by_date"}, suite.args(3))
+
+	rec, err := describeRequest(calls[1].Req, calls[1].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{}, rec.Key)
+
+	rec, err = describeRequest(calls[4].Req, calls[4].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "orders", rec.Space)
+	assert.Equal(suite.T(), []any{int8(7), "2024-10-08"}, rec.Key)
+}
+
+func (suite *DataMigrationTestSuite) args(call int) any {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[call].Req, calls[call].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec.Args
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesCheckpointError() {
+	ctx := contextWithMigrationArgs(suite.ctx, &Migration{ID: "migration-1"}, MigrationDirectionUp)
+	suite.opts.Resumable = true
+	suite.doer.AddResponseRaw([]interface{}{nil})
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	processed, err := RunBatches(ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.Equal(suite.T(), 1, processed)
+	assert.Equal(suite.T(), "batch 1: save checkpoint: set migrator state: tarantool error", err.Error())
+}
+
+func (suite *DataMigrationTestSuite) TestRunBatchesResumableOutsideMigrator() {
+	suite.opts.Resumable = true
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	processed, err := RunBatches(suite.ctx, suite.mock, DefaultOptions, suite.opts, suite.replaceAll)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, processed)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+}
+
+func (suite *DataMigrationTestSuite) TestNewBatchMigrateFunction() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	fn := NewBatchMigrateFunction(suite.opts, suite.replaceAll)
+	err := fn(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+}
+
+func (suite *DataMigrationTestSuite) TestLoggerFromContext() {
+	assert.Equal(suite.T(), DefaultLogger, LoggerFromContext(context.Background()))
+	assert.Equal(suite.T(), SilentLogger, LoggerFromContext(suite.ctx))
+}
+
+func TestDataMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(DataMigrationTestSuite))
+}
@@ -0,0 +1,123 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// destructivePatterns match calls which drop or truncate data in lua and sql migrations.
+var destructivePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`:\s*(drop|truncate)\s*\(`),
+	regexp.MustCompile(`box\.schema\.[a-z_.]*drop[a-z_]*\s*\(`),
+	regexp.MustCompile(`(?i)\b(drop\s+(table|index|view)|truncate\s+table)\b`),
+}
+
+// DestructiveCall is a destructive call found in the migration file.
+type DestructiveCall struct {
+	// File is the path of the migration file
+	File string
+	// Line is the line number in the file
+	Line int
+	// Code is the trimmed line
+	Code string
+}
+
+func (c DestructiveCall) String() string {
+	return fmt.Sprintf("%s:%d: %s", c.File, c.Line, c.Code)
+}
+
+// DestructiveMigrationsError is returned by Migrate when pending migrations contain
+// not acknowledged destructive calls.
+type DestructiveMigrationsError struct {
+	// Calls contains destructive calls in definition order
+	Calls []DestructiveCall
+}
+
+func (e *DestructiveMigrationsError) Error() string {
+	calls := make([]string, 0, len(e.Calls))
+	for _, call := range e.Calls {
+		calls = append(calls, call.String())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrDestructiveMigrations.Error(), strings.Join(calls, ", "))
+}
+
+func (e *DestructiveMigrationsError) Unwrap() error {
+	return ErrDestructiveMigrations
+}
+
+// scanDestructiveCalls finds destructive calls in the file, comment lines are skipped.
+// In single-file migrations only the Up section is scanned.
+func scanDestructiveCalls(path, data string, combined bool) []DestructiveCall {
+	var calls []DestructiveCall
+
+	up := !combined
+
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case combined && trimmed == MigrationSectionMarkerUp:
+			up = true
+		case combined && trimmed == MigrationSectionMarkerDown:
+			up = false
+		case !up || strings.HasPrefix(trimmed, migrationHeaderComment):
+		default:
+			for _, pattern := range destructivePatterns {
+				if pattern.MatchString(trimmed) {
+					calls = append(calls, DestructiveCall{File: path, Line: i + 1, Code: trimmed})
+
+					break
+				}
+			}
+		}
+	}
+
+	return calls
+}
+
+// checkDestructive returns DestructiveMigrationsError when pending migrations contain destructive calls
+// acknowledged neither by Migration.AllowDestructive nor by Options.AllowDestructive.
+func (m *Migrator) checkDestructive(ctx context.Context) error {
+	if m.opts.AllowDestructive {
+		return nil
+	}
+
+	var flagged []*Migration
+
+	for _, migration := range m.migrations {
+		if len(migration.Destructive) > 0 && !migration.AllowDestructive {
+			flagged = append(flagged, migration)
+		}
+	}
+
+	if len(flagged) == 0 {
+		return nil
+	}
+
+	records, err := m.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf(`check destructive migrations error: %w`, err)
+	}
+
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.ID] = true
+	}
+
+	var calls []DestructiveCall
+
+	for _, migration := range flagged {
+		if !applied[migration.ID] {
+			calls = append(calls, migration.Destructive...)
+		}
+	}
+
+	if len(calls) > 0 {
+		return &DestructiveMigrationsError{Calls: calls}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type DestructiveTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *DestructiveTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+
+	opts := DefaultOptions
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{
+			ID:          "migration-1",
+			Migrate:     NewGenericMigrateFunction("box.space.users:truncate()"),
+			Destructive: []DestructiveCall{{File: "migration-1.up.lua", Line: 3, Code: "box.space.users:truncate()"}},
+		},
+	}, WithLogger(SilentLogger), WithOptions(&opts))
+}
+
+func (suite *DestructiveTestSuite) TestScanLua() {
+	calls := scanDestructiveCalls("m.up.lua", "-- box.space.users:drop()\n"+
+		"box.space.users:truncate()\nbox.space.users.index.email:drop()\n"+
+		"box.schema.user.drop('guest')\nbox.space.users:insert({1})\n", false)
+	assert.Equal(suite.T(), []DestructiveCall{
+		{File: "m.up.lua", Line: 2, Code: "box.space.users:truncate()"},
+		{File: "m.up.lua", Line: 3, Code: "box.space.users.index.email:drop()"},
+		{File: "m.up.lua", Line: 4, Code: "box.schema.user.drop('guest')"},
+	}, calls)
+}
+
+func (suite *DestructiveTestSuite) TestScanCombined() {
+	calls := scanDestructiveCalls("m.lua", MigrationSectionMarkerUp+"\nbox.space.users:drop()\n"+
+		MigrationSectionMarkerDown+"\nbox.space.orders:drop()\n", true)
+	assert.Equal(suite.T(), []DestructiveCall{{File: "m.lua", Line: 2, Code: "box.space.users:drop()"}}, calls)
+}
+
+func (suite *DestructiveTestSuite) TestScanSQL() {
+	calls := scanDestructiveCalls("m.up.sql", "CREATE TABLE t (id INT PRIMARY KEY);\ndrop table users;\n"+
+		"TRUNCATE TABLE orders;", false)
+	assert.Len(suite.T(), calls, 2)
+	assert.Equal(suite.T(), 3, calls[1].Line)
+}
+
+func (suite *DestructiveTestSuite) TestMigrateFailsOnDestructive() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrDestructiveMigrations)
+	assert.Equal(suite.T(), "destructive migrations are not allowed: migration-1.up.lua:3: box.space.users:truncate()",
+		err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 2)
+}
+
+func (suite *DestructiveTestSuite) TestMigrateAppliedDestructive() {
+	body := newMigrationTupleStubResponseBody()
+	body[0][0] = "migration-1"
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][][]interface{}{body})
+	suite.doer.AddResponseRaw([][]interface{}{body[0]})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+}
+
+func (suite *DestructiveTestSuite) TestMigrateAcknowledged() {
+	suite.testable.migrations[0].AllowDestructive = true
+	for range 4 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+	}
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+}
+
+func (suite *DestructiveTestSuite) TestMigrateAllowedByOptions() {
+	suite.testable.opts.AllowDestructive = true
+	for range 4 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+	}
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+}
+
+func (suite *DestructiveTestSuite) TestMigrateCheckError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), "check destructive migrations error: list applied migrations: tarantool error",
+		err.Error())
+}
+
+func TestDestructiveTestSuite(t *testing.T) {
+	suite.Run(t, new(DestructiveTestSuite))
+}
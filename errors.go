@@ -13,3 +13,69 @@ var ErrNoAppliedMigrations = errors.New("no applied migrations")
 var ErrMigrationIDDoesNotExist = errors.New("tried to migrate to an ID that doesn't exist")
 var ErrWrongMigrationFileFormat = errors.New("wrong migration file format")
 var ErrWrongMigrationCmdFormat = errors.New("wrong migration cmd format")
+
+// ErrReplayMismatch is returned by ReplayPooler when a request differs from the recorded one.
+var ErrReplayMismatch = errors.New("request does not match recorded call")
+var ErrReplayExhausted = errors.New("no more recorded calls")
+var ErrReplayUnsupported = errors.New("operation is not supported by replay pooler")
+
+// ErrMigrationNotConfirmed is returned when a migration requires confirmation which was not given.
+var ErrMigrationNotConfirmed = errors.New("migration requires manual confirmation")
+var ErrWrongMigrationHeader = errors.New("wrong migration header")
+var ErrIrreversibleMigration = errors.New("migration is irreversible")
+var ErrMigrationDependencyNotFound = errors.New("migration dependency is not defined before migration")
+var ErrWrongMigrationSections = errors.New("wrong migration sections")
+var ErrMixedMigrationFormats = errors.New("migration is defined both as single file and as up/down files")
+var ErrWrongSQLScript = errors.New("wrong sql script")
+var ErrWrongMigrationTemplate = errors.New("wrong migration template")
+var ErrTemplateOptionsNotSet = errors.New("template uses options, set them with WithLoaderOptions")
+
+// ErrNoWritableInstances is returned by Rollout when pool has no connected masters.
+var ErrNoWritableInstances = errors.New("no writable instances in pool")
+
+// ErrVshardUnsupportedRequest is returned when request can not be forwarded to vshard storage.
+var ErrVshardUnsupportedRequest = errors.New("request is not supported by vshard storage pooler")
+
+// ErrReplicationLag is returned when replicas do not catch up with the master in time.
+var ErrReplicationLag = errors.New("replicas have not caught up with the master")
+
+// ErrMissingMigrations is wrapped by MissingMigrationsError.
+var ErrMissingMigrations = errors.New("migrations are not applied")
+
+// ErrPreflightFailed wraps all failed preflight checks.
+var ErrPreflightFailed = errors.New("preflight failed")
+var ErrNotConnected = errors.New("no connected instances for write mode")
+
+// ErrWrongTarantoolVersion is returned when version can't be parsed.
+var ErrWrongTarantoolVersion = errors.New("wrong tarantool version")
+var ErrTarantoolVersionMismatch = errors.New("tarantool version does not satisfy migration")
+
+// ErrBackgroundJobFailed is returned when the fiber of background migration raised an error.
+var ErrBackgroundJobFailed = errors.New("background job failed")
+
+// ErrBackgroundJobNotFound is returned when the job is missing in Options.JobsSpace.
+var ErrBackgroundJobNotFound = errors.New("background job not found")
+
+// ErrUndoJournalNotFound is returned when the undo journal of migration is missing or confirmed.
+var ErrUndoJournalNotFound = errors.New("undo journal not found")
+
+// ErrWrongRebuildOptions is returned when RebuildOptions are inconsistent.
+var ErrWrongRebuildOptions = errors.New("wrong rebuild options")
+
+// ErrRebuildNotSettled is returned when concurrent writes keep the capture log of the rebuild non-empty.
+var ErrRebuildNotSettled = errors.New("rebuild capture log is not settled")
+
+// ErrDestructiveMigrations is wrapped by DestructiveMigrationsError.
+var ErrDestructiveMigrations = errors.New("destructive migrations are not allowed")
+
+// ErrStoreLocked is returned when the store is locked by another migrator.
+var ErrStoreLocked = errors.New("migrations store is locked")
+
+// ErrMigrationAlreadyApplied is returned by Store.Insert when the record exists.
+var ErrMigrationAlreadyApplied = errors.New("migration is already applied")
+
+// ErrConnectorUnsupported is returned by the pooler of single connection when the doer lacks the method.
+var ErrConnectorUnsupported = errors.New("not supported by single connection")
+
+// ErrSchemaVersionDisabled is returned by WatchSchemaVersion when Options.SchemaVersionKey is empty.
+var ErrSchemaVersionDisabled = errors.New("schema version key is empty")
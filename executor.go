@@ -3,99 +3,130 @@ package tarantool_migrator
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/tarantool/go-tarantool/v3"
 	"github.com/tarantool/go-tarantool/v3/pool"
 )
 
 type executor interface {
-	createMigrationsSpaceIfNotExists(ctx context.Context, path string) error
-	hasAppliedMigration(ctx context.Context, migrationID string) (bool, error)
 	applyMigration(ctx context.Context, migration *Migration) error
 	rollbackMigration(ctx context.Context, migration *Migration) error
-	findLastAppliedMigration(ctx context.Context) (*migrationTuple, error)
+	listCheckpoints(ctx context.Context) ([]MigrationCheckpoint, error)
+	replicationState(ctx context.Context, vclock [][]uint64) (*replicationState, error)
+	setState(ctx context.Context, key string, value any) error
+	storeSchemaVersion(ctx context.Context, version SchemaVersion) error
+	preflightState(ctx context.Context) (*preflightState, error)
+	serverVersion(ctx context.Context, mode pool.Mode) (string, error)
+	clearUndo(ctx context.Context, migrationID string) error
 }
 
 type executorBase struct {
-	tt   pool.Pooler
-	opts *Options
+	tt    pool.Pooler
+	opts  *Options
+	store Store
 }
 
-func newExecutor(tt pool.Pooler, opts *Options) executor {
-	base := executorBase{tt: tt, opts: opts}
+func newExecutor(tt pool.Pooler, opts *Options, store Store) executor {
+	base := executorBase{tt: tt, opts: opts, store: store}
 
 	return &noTxExecutor{executorBase: base}
 }
 
-func (e *executorBase) createMigrationsSpaceIfNotExists(ctx context.Context, path string) error {
-	data, err := LuaFs.ReadFile(path)
+func (e *executorBase) listCheckpoints(ctx context.Context) ([]MigrationCheckpoint, error) {
+	var checkpoints [][]MigrationCheckpoint
+
+	data, err := LuaFs.ReadFile(listCheckpointsPath)
 	if err != nil {
-		return fmt.Errorf("read lua script: %w", err)
+		return nil, fmt.Errorf("read lua script: %w", err)
 	}
 
-	migrationSpaceRequest := string(data)
-	migrationSpaceRequest = strings.ReplaceAll(migrationSpaceRequest, "_migrations_space_", e.opts.MigrationsSpace)
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{e.opts.StateSpace, checkpointKeyPrefix})
 
-	_, err = e.tt.Do(tarantool.NewEvalRequest(migrationSpaceRequest).Context(ctx), e.opts.WriteMode).Get()
+	err = e.tt.Do(req, e.opts.ReadMode).GetTyped(&checkpoints)
 	if err != nil {
-		return fmt.Errorf("exec create migrations space: %w", err)
+		return nil, fmt.Errorf("list checkpoints: %w", err)
 	}
 
-	return nil
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+
+	return checkpoints[0], nil
 }
 
-func (e *executorBase) hasAppliedMigration(ctx context.Context, migrationID string) (bool, error) {
-	var tuples []migrationTuple
+func (e *executorBase) replicationState(ctx context.Context, vclock [][]uint64) (*replicationState, error) {
+	var state replicationState
+
+	data, err := LuaFs.ReadFile(replicationStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
 
-	req := tarantool.NewSelectRequest(e.opts.MigrationsSpace).Context(ctx).Key([]any{migrationID})
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx)
+	if vclock != nil {
+		req = req.Args([]any{vclock})
+	}
 
-	err := e.tt.Do(req, e.opts.ReadMode).GetTyped(&tuples)
+	err = e.tt.Do(req, e.opts.WriteMode).GetTyped(&state)
 	if err != nil {
-		return false, fmt.Errorf("check applied migration: %w", err)
+		return nil, fmt.Errorf("get replication state: %w", err)
 	}
 
-	return len(tuples) > 0, nil
+	return &state, nil
+}
+
+func (e *executorBase) setState(ctx context.Context, key string, value any) error {
+	return setMigratorState(ctx, e.tt, *e.opts, key, value, true)
+}
+
+// storeSchemaVersion keeps the version in the replicated state space, instances publish it by themselves.
+func (e *executorBase) storeSchemaVersion(ctx context.Context, version SchemaVersion) error {
+	return setMigratorState(ctx, e.tt, *e.opts, e.opts.SchemaVersionKey, version, false)
 }
 
-func (e *executorBase) insertMigration(ctx context.Context, tt pool.Pooler, migrationID string) error {
-	tuple := newMigrationTuple(migrationID)
+func (e *executorBase) preflightState(ctx context.Context) (*preflightState, error) {
+	var state preflightState
 
-	_, err := tt.Do(tarantool.NewInsertRequest(e.opts.MigrationsSpace).Context(ctx).Tuple(tuple.ToSlice()),
-		e.opts.WriteMode,
-	).Get()
+	data, err := LuaFs.ReadFile(preflightPath)
 	if err != nil {
-		return fmt.Errorf("insert migration record: %w", err)
+		return nil, fmt.Errorf("read lua script: %w", err)
 	}
 
-	return nil
-}
-
-func (e *executorBase) deleteMigration(ctx context.Context, tt pool.Pooler, migrationID string) error {
-	req := tarantool.NewDeleteRequest(e.opts.MigrationsSpace).Context(ctx).Key([]any{migrationID})
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{e.opts.MigrationsSpace, e.opts.DryRun})
 
-	_, err := tt.Do(req, e.opts.WriteMode).Get()
+	err = e.tt.Do(req, e.opts.WriteMode).GetTyped(&state)
 	if err != nil {
-		return fmt.Errorf("delete migration record: %w", err)
+		return nil, fmt.Errorf("check instance: %w", err)
 	}
 
-	return nil
+	return &state, nil
 }
 
-func (e *executorBase) findLastAppliedMigration(ctx context.Context) (*migrationTuple, error) {
-	var tuples []migrationTuple
+// serverVersion returns box.info.version of the instance selected by the mode.
+func (e *executorBase) serverVersion(ctx context.Context, mode pool.Mode) (string, error) {
+	var versions []string
 
-	cmd := "return box.space._migrations_space_.index.id:max()"
-	expr := strings.ReplaceAll(cmd, "_migrations_space_", e.opts.MigrationsSpace)
+	data, err := LuaFs.ReadFile(serverVersionPath)
+	if err != nil {
+		return "", fmt.Errorf("read lua script: %w", err)
+	}
 
-	err := e.tt.Do(tarantool.NewEvalRequest(expr).Context(ctx), e.opts.ReadMode).GetTyped(&tuples)
+	err = e.tt.Do(tarantool.NewEvalRequest(string(data)).Context(ctx), mode).GetTyped(&versions)
 	if err != nil {
-		return nil, fmt.Errorf("find last applied migration: %w", err)
+		return "", fmt.Errorf("get server version: %w", err)
 	}
 
-	if len(tuples) == 0 {
-		return nil, ErrNoAppliedMigrations
+	if len(versions) == 0 {
+		return "", ErrWrongTarantoolVersion
 	}
 
-	return &tuples[0], nil
+	return versions[0], nil
+}
+
+func (e *executorBase) clearUndo(ctx context.Context, migrationID string) error {
+	if err := evalLuaScript(ctx, e.tt, *e.opts, undoClearPath, e.opts.UndoSpace, migrationID); err != nil {
+		return fmt.Errorf("clear undo journal: %w", err)
+	}
+
+	return nil
 }
@@ -14,11 +14,21 @@ func (e *noTxExecutor) applyMigration(ctx context.Context, migration *Migration)
 		return nil
 	}
 
-	if err := migration.Migrate(ctx, e.tt, *e.opts); err != nil {
-		return fmt.Errorf("user migrate: %w", err)
+	mctx, cancel := migration.context(contextWithMigrationArgs(ctx, migration, MigrationDirectionUp))
+	defer cancel()
+
+	err := e.withUndoJournal(ctx, migration, func() error {
+		if err := migration.Migrate(mctx, e.tt, migration.options(*e.opts)); err != nil {
+			return fmt.Errorf("user migrate: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return e.insertMigration(ctx, e.tt, migration.ID)
+	return e.store.Insert(ctx, migration.ID)
 }
 
 func (e *noTxExecutor) rollbackMigration(ctx context.Context, migration *Migration) error {
@@ -26,9 +36,21 @@ func (e *noTxExecutor) rollbackMigration(ctx context.Context, migration *Migrati
 		return nil
 	}
 
-	if err := migration.Rollback(ctx, e.tt, *e.opts); err != nil {
+	mctx, cancel := migration.context(contextWithMigrationArgs(ctx, migration, MigrationDirectionDown))
+	defer cancel()
+
+	if err := migration.rollbackFunc()(mctx, e.tt, migration.options(*e.opts)); err != nil {
 		return fmt.Errorf("user rollback: %w", err)
 	}
 
-	return e.deleteMigration(ctx, e.tt, migration.ID)
+	if err := e.store.Delete(ctx, migration.ID); err != nil {
+		return err
+	}
+
+	// the journal is cleared after the record, so rollback interrupted before can be repeated
+	if len(migration.UndoSpaces) > 0 {
+		return e.clearUndo(ctx, migration.ID)
+	}
+
+	return nil
 }
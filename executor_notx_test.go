@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kachit/tarantool-migrator/mocks"
 	"github.com/stretchr/testify/assert"
@@ -25,15 +26,13 @@ type NoTxExecutorTestSuite struct {
 func (suite *NoTxExecutorTestSuite) SetupTest() {
 	suite.mock = &mocks.PoolerMock{}
 	suite.ctx = context.Background()
+	opts := &Options{
+		MigrationsSpace: "migrations",
+		ReadMode:        pool.ModeAny,
+		WriteMode:       pool.ModeRW,
+	}
 	suite.testable = &noTxExecutor{
-		executorBase: executorBase{
-			tt: suite.mock,
-			opts: &Options{
-				MigrationsSpace: "migrations",
-				ReadMode:        pool.ModeAny,
-				WriteMode:       pool.ModeRW,
-			},
-		},
+		executorBase: executorBase{tt: suite.mock, opts: opts, store: newTarantoolStore(suite.mock, opts)},
 	}
 }
 
@@ -71,7 +70,7 @@ func (suite *NoTxExecutorTestSuite) TestApplyMigrationWithMigrateError() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations migration-with-migrate-error up map[]}]", fmt.Sprintf("%v", argsField))
 }
 
 func (suite *NoTxExecutorTestSuite) TestApplyMigrationWithInsertError() {
@@ -99,7 +98,7 @@ func (suite *NoTxExecutorTestSuite) TestApplyMigrationWithInsertError() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations migration-with-insert-error up map[]}]", fmt.Sprintf("%v", argsField))
 
 	insertReqRef := reflect.ValueOf(calls[1].Req)
 	insertReq := insertReqRef.Interface().(tarantool.InsertRequest)
@@ -133,7 +132,7 @@ func (suite *NoTxExecutorTestSuite) TestApplyMigrationSuccessful() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations apply-migration-successful up map[]}]", fmt.Sprintf("%v", argsField))
 
 	insertReqRef := reflect.ValueOf(calls[1].Req)
 	insertReq := insertReqRef.Interface().(tarantool.InsertRequest)
@@ -177,7 +176,7 @@ func (suite *NoTxExecutorTestSuite) TestRollbackMigrationWithRollbackError() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations migration-with-rollback-error down map[]}]", fmt.Sprintf("%v", argsField))
 }
 
 func (suite *NoTxExecutorTestSuite) TestRollbackMigrationWithDeleteError() {
@@ -205,7 +204,7 @@ func (suite *NoTxExecutorTestSuite) TestRollbackMigrationWithDeleteError() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations migration-with-delete-error down map[]}]", fmt.Sprintf("%v", argsField))
 
 	reqRef := reflect.ValueOf(calls[1].Req)
 	req := reqRef.Interface().(tarantool.DeleteRequest)
@@ -241,7 +240,7 @@ func (suite *NoTxExecutorTestSuite) TestRollbackMigrationSuccessful() {
 	exprField := migrateReqRef.FieldByName("expr")
 	assert.Equal(suite.T(), "box.info", exprField.String())
 	argsField := migrateReqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[{migrations migration-rollback-success down map[]}]", fmt.Sprintf("%v", argsField))
 
 	reqRef := reflect.ValueOf(calls[1].Req)
 	req := reqRef.Interface().(tarantool.DeleteRequest)
@@ -296,6 +295,33 @@ func (suite *NoTxExecutorTestSuite) TestNewGenericMigrateFunctionErrorExecute()
 	assert.Equal(suite.T(), "box.info", exprField.String())
 }
 
+func (suite *NoTxExecutorTestSuite) TestApplyMigrationWithOverrides() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	writeMode := pool.ModePreferRW
+	var hasDeadline bool
+	err := suite.testable.applyMigration(suite.ctx, &Migration{
+		ID:        "migration-with-overrides",
+		Timeout:   time.Minute,
+		WriteMode: &writeMode,
+		Migrate: func(ctx context.Context, tt pool.Pooler, opts Options) error {
+			_, hasDeadline = ctx.Deadline()
+			return NewGenericMigrateFunction("box.info")(ctx, tt, opts)
+		},
+	})
+	calls := suite.mock.DoCalls()
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), hasDeadline)
+	assert.Len(suite.T(), calls, 2)
+	assert.Equal(suite.T(), pool.ModePreferRW, calls[0].Mode)
+	assert.Equal(suite.T(), pool.ModeRW, calls[1].Mode)
+}
+
 func TestNoTxExecutorTestSuite(t *testing.T) {
 	suite.Run(t, new(NoTxExecutorTestSuite))
 }
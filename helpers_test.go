@@ -2,9 +2,16 @@ package tarantool_migrator
 
 import (
 	"fmt"
+	"path/filepath"
+	"testing"
 	"time"
 
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/go-tarantool/v3"
 	"github.com/tarantool/go-tarantool/v3/datetime"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
 )
 
 func newMigrationTupleStubResponseBody() [][]interface{} {
@@ -18,3 +25,95 @@ func newMigrationTupleStubResponseBody() [][]interface{} {
 		},
 	}
 }
+
+// instancesPoolerStub routes DoOn calls to per-instance MockDoer.
+type instancesPoolerStub struct {
+	*mocks.PoolerMock
+	info  map[string]pool.Info
+	doers map[string]test_helpers.MockDoer
+	calls []string
+}
+
+func (s *instancesPoolerStub) Info() map[string]pool.Info {
+	return s.info
+}
+
+func (s *instancesPoolerStub) DoOn(req tarantool.Request, name string) tarantool.Future {
+	s.calls = append(s.calls, name)
+
+	return s.doers[name].Do(req)
+}
+
+func newInstancesPoolerStub(t *testing.T, roles map[string]pool.Role) *instancesPoolerStub {
+	stub := &instancesPoolerStub{
+		PoolerMock: &mocks.PoolerMock{},
+		info:       make(map[string]pool.Info),
+		doers:      make(map[string]test_helpers.MockDoer),
+	}
+
+	for name, role := range roles {
+		stub.info[name] = pool.Info{ConnectedNow: true, Role: role, Instance: pool.Instance{Name: name}}
+		stub.doers[name] = test_helpers.NewMockDoer(t)
+	}
+
+	return stub
+}
+
+// goldenPooler replays testdata/golden/<name>.json, with -update it records the file from MockDoer responses.
+type goldenPooler struct {
+	pool.Pooler
+	path     string
+	recorder *RecordingPooler
+	replay   *ReplayPooler
+}
+
+func newGoldenPooler(t *testing.T, name string, responses ...any) *goldenPooler {
+	gp := &goldenPooler{path: filepath.Join("testdata", "golden", name+".json")}
+
+	if *updateGolden {
+		doer := test_helpers.NewMockDoer(t)
+		for _, response := range responses {
+			doer.AddResponseRaw(response)
+		}
+
+		gp.recorder = NewRecordingPooler(&mocks.PoolerMock{
+			DoFunc: func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+				return doer.Do(req)
+			},
+			ConnectedNowFunc: func(mode pool.Mode) (bool, error) {
+				return true, nil
+			},
+		})
+		gp.Pooler = gp.recorder
+
+		return gp
+	}
+
+	replay, err := NewReplayPoolerFromGoldenFile(gp.path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	gp.replay = replay
+	gp.Pooler = replay
+
+	return gp
+}
+
+// check writes the recorded golden file or asserts that every recorded call was replayed.
+func (gp *goldenPooler) check(t *testing.T) {
+	if gp.recorder != nil {
+		assert.NoError(t, gp.recorder.WriteGoldenFile(gp.path))
+
+		return
+	}
+
+	assert.Equal(t, 0, gp.replay.Remaining())
+}
+
+// newAppliedMigrationResponseBody returns the migrations space tuple with fixed execution time for golden files.
+func newAppliedMigrationResponseBody(id string) [][]interface{} {
+	dt, _ := datetime.NewDatetime(time.Date(2024, 10, 8, 23, 45, 0, 0, time.UTC))
+
+	return [][]interface{}{{id, dt}}
+}
@@ -1,13 +1,20 @@
 package tarantool_migrator
 
 import (
+	"context"
 	"embed"
 	"fmt"
+	"path"
 	"strings"
+	"text/template"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
 )
 
 type EmbedFsLoader struct {
-	fs embed.FS
+	fs    embed.FS
+	opts  *Options
+	funcs template.FuncMap
 }
 
 func (fl *EmbedFsLoader) LoadMigrations(path string) (MigrationsCollection, error) {
@@ -18,6 +25,7 @@ func (fl *EmbedFsLoader) LoadMigrations(path string) (MigrationsCollection, erro
 
 	migrations := make(MigrationsCollection, 0)
 	coll := make(map[string]*Migration)
+	combined := make(map[string]bool)
 
 	for _, file := range files {
 		if file.IsDir() || strings.HasPrefix(file.Name(), MigrationFilePrefixExcluded) {
@@ -37,6 +45,9 @@ func (fl *EmbedFsLoader) LoadMigrations(path string) (MigrationsCollection, erro
 				ID: mgrFile.GetName(),
 			}
 			coll[mgrFile.GetName()] = migration
+			combined[mgrFile.GetName()] = mgrFile.IsCombined()
+		} else if combined[mgrFile.GetName()] != mgrFile.IsCombined() {
+			return nil, fmt.Errorf("parse migration file %q: %w", file.Name(), ErrMixedMigrationFormats)
 		}
 
 		fileData, err := fl.fs.ReadFile(mgrFile.GetPath())
@@ -44,10 +55,16 @@ func (fl *EmbedFsLoader) LoadMigrations(path string) (MigrationsCollection, erro
 			return nil, fmt.Errorf("read migration file: %w", err)
 		}
 
-		if mgrFile.GetCmd() == MigrationFileSuffixUp {
-			migration.Migrate = NewGenericMigrateFunction(string(fileData))
-		} else {
-			migration.Rollback = NewGenericMigrateFunction(string(fileData))
+		if mgrFile.IsTemplate() {
+			fileData, err = fl.renderMigrationFile(mgrFile, fileData)
+			if err != nil {
+				return nil, fmt.Errorf("parse migration file %q: %w", file.Name(), err)
+			}
+		}
+
+		err = fl.applyMigrationFile(mgrFile, fileData, migration)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration file %q: %w", file.Name(), err)
 		}
 	}
 
@@ -60,6 +77,111 @@ func (fl *EmbedFsLoader) LoadMigrations(path string) (MigrationsCollection, erro
 	return migrations, nil
 }
 
-func NewEmbedFsLoader(fs embed.FS) *EmbedFsLoader {
-	return &EmbedFsLoader{fs}
+func (fl *EmbedFsLoader) renderMigrationFile(mgrFile *MigrationFile, fileData []byte) ([]byte, error) {
+	name := path.Base(mgrFile.GetPath())
+	if fl.opts == nil {
+		return renderMigrationTemplate(name, string(fileData), templateDataWithoutOptions{ID: mgrFile.GetName()}, fl.funcs)
+	}
+
+	data := TemplateData{
+		ID:              mgrFile.GetName(),
+		MigrationsSpace: fl.opts.MigrationsSpace,
+		Options:         *fl.opts,
+		Container:       fl.opts.MigrationsContainer,
+	}
+
+	return renderMigrationTemplate(name, string(fileData), data, fl.funcs)
+}
+
+func (fl *EmbedFsLoader) applyMigrationFile(mgrFile *MigrationFile, fileData []byte, migration *Migration) error {
+	err := mgrFile.ParseHeader(fileData)
+	if err != nil {
+		return err
+	}
+
+	mgrFile.apply(migration)
+
+	if mgrFile.GetCmd() != MigrationFileSuffixDown {
+		calls := scanDestructiveCalls(mgrFile.GetPath(), string(fileData), mgrFile.IsCombined())
+		migration.Destructive = append(migration.Destructive, calls...)
+	}
+
+	if mgrFile.IsCombined() {
+		up, down, err := parseMigrationSections(string(fileData))
+		if err != nil {
+			return err
+		}
+
+		migration.Migrate, err = newFileMigrateFunction(mgrFile, up)
+		if err != nil {
+			return err
+		}
+
+		if down != "" {
+			migration.Rollback, err = newFileMigrateFunction(mgrFile, down)
+		}
+
+		return err
+	}
+
+	fn, err := newFileMigrateFunction(mgrFile, string(fileData))
+	if err != nil {
+		return err
+	}
+
+	if mgrFile.GetCmd() == MigrationFileSuffixUp {
+		migration.Migrate = fn
+	} else {
+		migration.Rollback = fn
+	}
+
+	return nil
+}
+
+func newFileMigrateFunction(
+	mgrFile *MigrationFile, body string,
+) (func(context.Context, pool.Pooler, Options) error, error) {
+	if mgrFile.GetExt() == MigrationFileExtSQL {
+		if mgrFile.IsBackground() {
+			return nil, fmt.Errorf("%w: background is supported by lua migrations only", ErrWrongMigrationHeader)
+		}
+
+		statements, err := splitSQLStatements(body)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSQLMigrateFunction(statements), nil
+	}
+
+	if mgrFile.IsBackground() {
+		return NewBackgroundMigrateFunction(body), nil
+	}
+
+	return NewGenericMigrateFunction(body), nil
+}
+
+// NewEmbedFsLoader creates the loader of migrations files. Templated migrations using .MigrationsSpace, .Options
+// or .Container need the options of the migrator passed with WithLoaderOptions, they fail to load without them.
+func NewEmbedFsLoader(fs embed.FS, options ...func(*EmbedFsLoader)) *EmbedFsLoader {
+	fl := &EmbedFsLoader{fs: fs}
+	for _, opt := range options {
+		opt(fl)
+	}
+
+	return fl
+}
+
+// WithLoaderOptions sets options used to render templated migrations, pass the options of the migrator.
+func WithLoaderOptions(op *Options) func(loader *EmbedFsLoader) {
+	return func(fl *EmbedFsLoader) {
+		fl.opts = op
+	}
+}
+
+// WithTemplateFuncs adds custom helper functions to templated migrations.
+func WithTemplateFuncs(funcs template.FuncMap) func(loader *EmbedFsLoader) {
+	return func(fl *EmbedFsLoader) {
+		fl.funcs = funcs
+	}
 }
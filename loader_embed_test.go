@@ -1,9 +1,17 @@
 package tarantool_migrator
 
 import (
+	"context"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/kachit/tarantool-migrator/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"testing"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
 )
 
 type EmbedFsLoaderTestSuite struct {
@@ -51,6 +59,156 @@ func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValid() {
 	assert.Equal(suite.T(), result[1].ID, "202410091201_test_migration_2")
 }
 
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValidHeader() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/valid-header")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), 5*time.Minute, result[0].Timeout)
+	assert.Equal(suite.T(), pool.ModePreferRW, *result[0].WriteMode)
+	assert.Nil(suite.T(), result[0].ReadMode)
+	assert.True(suite.T(), *result[0].Transactional)
+	assert.True(suite.T(), result[0].RequiresConfirmation)
+	assert.False(suite.T(), result[0].Maintenance)
+	assert.NotNil(suite.T(), result[0].Rollback)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidHeader() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/invalid-header")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.up.lua": wrong migration header: `+
+		`line 1: directive "timeout": time: invalid duration "forever"`, err.Error())
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValidMetadata() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/valid-metadata")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "create test space", result[0].Description)
+	assert.Equal(suite.T(), "John Doe", result[0].Author)
+	assert.Equal(suite.T(), []string{"test", "ddl"}, result[0].Tags)
+	assert.False(suite.T(), *result[0].Transactional)
+	assert.Equal(suite.T(), []string{"202410082345_test_migration_1"}, result[1].Depends)
+	assert.True(suite.T(), result[1].Irreversible)
+	assert.Empty(suite.T(), result[0].Destructive)
+	assert.Equal(suite.T(), []DestructiveCall{{
+		File: "lua/stubs/valid-metadata/202410091201_test_migration_2.up.lua",
+		Line: 4,
+		Code: "box.schema.drop_space('test-1')",
+	}}, result[1].Destructive)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidMetadata() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/invalid-metadata")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.up.lua": wrong migration header: `+
+		`line 2: directive "depends": empty item in list "202410082345_test_migration_0,"`, err.Error())
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsDestructiveDownHeader() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/valid-destructive")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.False(suite.T(), result[0].AllowDestructive)
+	assert.Len(suite.T(), result[0].Destructive, 1)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValidCombined() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/valid-combined")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "202410082345_test_migration_1", result[0].ID)
+	assert.Equal(suite.T(), "create test space", result[0].Description)
+	assert.NotNil(suite.T(), result[0].Migrate)
+	assert.NotNil(suite.T(), result[0].Rollback)
+	assert.Empty(suite.T(), result[0].Destructive)
+	assert.Equal(suite.T(), "202410091201_test_migration_2", result[1].ID)
+	assert.NotNil(suite.T(), result[1].Migrate)
+	assert.Nil(suite.T(), result[1].Rollback)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidCombinedMixed() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/invalid-combined-mixed")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.lua": `+
+		`migration is defined both as single file and as up/down files`, err.Error())
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidCombinedSections() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/invalid-combined-sections")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.lua": `+
+		`wrong migration sections: line 3: duplicate "up" section`, err.Error())
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValidSQL() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/valid-sql")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "202410082345_test_migration_1", result[0].ID)
+	assert.Nil(suite.T(), result[0].Rollback)
+	assert.Equal(suite.T(), "202410091201_test_migration_2", result[1].ID)
+	assert.Equal(suite.T(), "create users table", result[1].Description)
+	assert.NotNil(suite.T(), result[1].Migrate)
+	assert.NotNil(suite.T(), result[1].Rollback)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidSQL() {
+	result, err := suite.testable.LoadMigrations("lua/stubs/invalid-sql")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.up.sql": `+
+		`wrong sql script: line 1: unterminated quoted string`, err.Error())
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsValidTemplate() {
+	opts := DefaultOptions
+	opts.MigrationsSpace = "schema_migrations"
+	opts.MigrationsContainer = map[string]any{"engine": "vinyl"}
+	loader := NewEmbedFsLoader(LuaFs, WithLoaderOptions(&opts), WithTemplateFuncs(template.FuncMap{
+		"prefix": func(name string) string { return "app_" + name },
+	}))
+	result, err := loader.LoadMigrations("lua/stubs/valid-template")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "create app_users space", result[0].Description)
+
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([]interface{}{})
+	recorder := NewRecordingPooler(&mocks.PoolerMock{DoFunc: func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}})
+	err = result[0].Migrate(context.Background(), recorder, opts)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "-- description: create app_users space\n"+
+		"box.schema.create_space(\"app_users\", { engine = \"vinyl\" })\n"+
+		"box.space[\"schema_migrations\"]:len()\n", recorder.Requests()[0].Expr)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsTemplateWithoutOptions() {
+	loader := NewEmbedFsLoader(LuaFs, WithTemplateFuncs(template.FuncMap{
+		"prefix": func(name string) string { return "app_" + name },
+	}))
+	result, err := loader.LoadMigrations("lua/stubs/valid-template")
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, ErrTemplateOptionsNotSet)
+}
+
+func (suite *EmbedFsLoaderTestSuite) TestLoadMigrationsInvalidTemplate() {
+	opts := DefaultOptions
+	loader := NewEmbedFsLoader(LuaFs, WithLoaderOptions(&opts))
+	result, err := loader.LoadMigrations("lua/stubs/invalid-template")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `parse migration file "202410082345_test_migration_1.up.lua.tmpl": wrong migration template: `+
+		`template: 202410082345_test_migration_1.up.lua.tmpl:1:58: executing `+
+		`"202410082345_test_migration_1.up.lua.tmpl" at <.Container.engine>: `+
+		`map has no entry for key "engine"`, err.Error())
+}
+
 func TestEmbedFsLoaderTestSuite(t *testing.T) {
 	suite.Run(t, new(EmbedFsLoaderTestSuite))
 }
@@ -1,6 +1,7 @@
 package tarantool_migrator
 
 import (
+	"context"
 	"io"
 	"log/slog"
 	"os"
@@ -14,3 +15,18 @@ var SilentLogger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions
 func formatDurationToMs(d time.Duration) float64 {
 	return float64(d.Nanoseconds()) / 1e6
 }
+
+type loggerKey struct{}
+
+func contextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the migrator logger inside migrate and rollback functions, DefaultLogger otherwise.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return DefaultLogger
+}
@@ -0,0 +1,21 @@
+local space_name, job_id = ...
+
+if box.space[space_name] == nil then
+    return nil
+end
+
+local job = box.space[space_name]:get(job_id)
+if job == nil then
+    return nil
+end
+
+local status, err = job.status, job.error
+
+if status == 'running' then
+    local jobs = rawget(_G, '__migrator_jobs') or {}
+    if jobs[job_id] == nil or jobs[job_id]:status() == 'dead' then
+        status, err = 'failed', 'job fiber is lost, instance was restarted'
+    end
+end
+
+return {job_id, status, err}
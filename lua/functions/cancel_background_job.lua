@@ -0,0 +1,12 @@
+local job_id = ...
+
+local jobs = rawget(_G, '__migrator_jobs') or {}
+local job = jobs[job_id]
+
+if job == nil or job:status() == 'dead' then
+    return false
+end
+
+job:cancel()
+
+return true
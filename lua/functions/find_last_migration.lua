@@ -1 +1,3 @@
-return box.space._migrations_space_.index.id:max()
\ No newline at end of file
+local space_name = ...
+
+return box.space[space_name].index.id:max()
@@ -0,0 +1,12 @@
+local space_name, key = ...
+
+if box.space[space_name] == nil then
+    return nil
+end
+
+local tuple = box.space[space_name]:get({key})
+if tuple == nil then
+    return nil
+end
+
+return tuple[2]
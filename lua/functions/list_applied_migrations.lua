@@ -0,0 +1,7 @@
+local space_name = ...
+
+if box.space[space_name] == nil then
+    return {}
+end
+
+return box.space[space_name]:select()
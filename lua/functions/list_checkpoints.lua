@@ -0,0 +1,17 @@
+local space_name, prefix = ...
+
+local checkpoints = {}
+
+if box.space[space_name] == nil then
+    return checkpoints
+end
+
+for _, tuple in box.space[space_name]:pairs({prefix}, {iterator = 'GE'}) do
+    if tuple[1]:sub(1, #prefix) ~= prefix then
+        break
+    end
+
+    table.insert(checkpoints, tuple[2])
+end
+
+return checkpoints
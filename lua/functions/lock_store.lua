@@ -0,0 +1,21 @@
+local space_name, key, owner = ...
+
+if box.space[space_name] == nil then
+    box.schema.space.create(space_name, {
+        if_not_exists = true,
+        format = {
+            {name = 'key', type = 'string'},
+            {name = 'value', type = 'any'},
+        },
+    })
+    box.space[space_name]:create_index('primary', {parts = {'key'}, if_not_exists = true})
+end
+
+local current = box.space[space_name]:get(key)
+if current ~= nil and current.value.owner ~= owner then
+    return {false, current.value.owner}
+end
+
+box.space[space_name]:replace({key, {owner = owner, locked_at = os.time()}})
+
+return {true, owner}
@@ -0,0 +1,64 @@
+local space_name, dry_run = ...
+
+local problems = {}
+
+-- dry run writes nothing, so it can check a read-only instance
+if box.info.ro and not dry_run then
+    table.insert(problems, 'instance is read-only')
+end
+
+if box.info.status ~= 'running' then
+    table.insert(problems, string.format('instance status is "%s"', box.info.status))
+end
+
+local function has_privilege(name, is_role, privilege, object_type, object_name, seen)
+    if name == 'admin' then
+        return true
+    end
+
+    local info = is_role and box.schema.role.info(name) or box.schema.user.info(name)
+
+    for _, grant in ipairs(info) do
+        local privileges, grant_type, grant_name = grant[1], grant[2], grant[3]
+
+        if grant_type == 'role' and privileges:find('execute', 1, true) then
+            if grant_name == 'super' then
+                return true
+            end
+
+            if not seen[grant_name] then
+                seen[grant_name] = true
+                if has_privilege(grant_name, true, privilege, object_type, object_name, seen) then
+                    return true
+                end
+            end
+        elseif privileges:find(privilege, 1, true) and (grant_type == 'universe' or
+            (grant_type == object_type and (grant_name == nil or grant_name == '' or grant_name == object_name))) then
+            return true
+        end
+    end
+
+    return false
+end
+
+local user = box.session.effective_user()
+
+local ok, err = pcall(function()
+    local space = box.space[space_name]
+
+    -- spaces are created by the migrator only when the migrations space is missing
+    if space == nil and not has_privilege(user, false, 'create', 'space', '', {}) then
+        table.insert(problems, string.format('user "%s" can not create spaces', user))
+    end
+
+    if space ~= nil and box.space._space:get(space.id)[2] ~= box.session.euid() and
+        not has_privilege(user, false, 'write', 'space', space_name, {}) then
+        table.insert(problems, string.format('user "%s" can not write to space "%s"', user, space_name))
+    end
+end)
+
+if not ok then
+    table.insert(problems, string.format('can not read privileges of user "%s": %s', user, err))
+end
+
+return problems, box.info.version
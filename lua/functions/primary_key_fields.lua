@@ -0,0 +1,17 @@
+local space_name = ...
+
+local space = box.space[space_name]
+if space == nil then
+    error(string.format('space %q does not exist', space_name))
+end
+
+if space.index[0] == nil then
+    error(string.format('space %q has no primary index', space_name))
+end
+
+local fields = {}
+for _, part in ipairs(space.index[0].parts) do
+    table.insert(fields, part.fieldno - 1)
+end
+
+return fields
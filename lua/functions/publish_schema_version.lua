@@ -0,0 +1,77 @@
+local space_name, key = ...
+
+if box.broadcast == nil then
+    return false
+end
+
+local fiber = require('fiber')
+
+if rawget(_G, '__migrator_schema_version') == nil then
+    rawset(_G, '__migrator_schema_version', {})
+end
+
+-- triggers of the previous call are replaced, so the function can be called again
+local triggers = rawget(_G, '__migrator_schema_version')
+local name = space_name .. '.' .. key
+local installed = triggers[name] or {}
+triggers[name] = installed
+
+local function publish()
+    local space = box.space[space_name]
+    local tuple = space ~= nil and space:get({key}) or nil
+    box.broadcast(key, tuple ~= nil and tuple[2] or nil)
+end
+
+local function on_replace(old, new)
+    local tuple = new or old
+    if tuple ~= nil and tuple[1] == key then
+        -- rows of the state space are replicated, so every instance publishes committed versions
+        box.on_commit(function()
+            fiber.new(publish)
+        end)
+    end
+end
+
+local function attach()
+    local space = box.space[space_name]
+    if space == nil then
+        return false
+    end
+
+    if installed.space ~= nil then
+        pcall(space.on_replace, space, nil, installed.space)
+    end
+
+    space:on_replace(on_replace)
+    installed.space = on_replace
+    publish()
+
+    return true
+end
+
+if attach() then
+    if installed.schema ~= nil then
+        pcall(box.space._space.on_replace, box.space._space, nil, installed.schema)
+        installed.schema = nil
+    end
+
+    return true
+end
+
+-- the state space is created by the first migration, attach to it then
+local function on_schema(_, new)
+    if new ~= nil and new[3] == space_name then
+        box.on_commit(function()
+            fiber.new(attach)
+        end)
+    end
+end
+
+if installed.schema ~= nil then
+    pcall(box.space._space.on_replace, box.space._space, nil, installed.schema)
+end
+
+box.space._space:on_replace(on_schema)
+installed.schema = on_schema
+
+return true
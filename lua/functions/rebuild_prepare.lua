@@ -0,0 +1,55 @@
+local space_name, shadow_name, old_name, log_name, format, indexes, capture = ...
+
+local space = box.space[space_name]
+if space == nil then
+    error(string.format('space %q does not exist', space_name))
+end
+
+-- the old space is left by the swap, copying again would transform already transformed tuples
+if box.space[old_name] ~= nil then
+    error(string.format('space %q already exists, the space is already rebuilt or %q is not dropped',
+        old_name, old_name))
+end
+
+-- copying always starts over, drop the shadow left by interrupted run
+if box.space[shadow_name] ~= nil then
+    box.space[shadow_name]:drop()
+end
+
+local shadow = box.schema.space.create(shadow_name, {engine = space.engine, format = format})
+for _, index in ipairs(indexes) do
+    shadow:create_index(index.name, index.options)
+end
+
+if rawget(_G, '__migrator_rebuild') == nil then
+    rawset(_G, '__migrator_rebuild', {})
+end
+
+local triggers = rawget(_G, '__migrator_rebuild')
+if triggers[space_name] ~= nil then
+    space:on_replace(nil, triggers[space_name])
+    triggers[space_name] = nil
+end
+
+if box.space[log_name] ~= nil then
+    box.space[log_name]:drop()
+end
+
+if not capture then
+    return true
+end
+
+local log = box.schema.space.create(log_name, {
+    format = {
+        {name = 'id', type = 'unsigned'},
+        {name = 'old', type = 'any', is_nullable = true},
+        {name = 'new', type = 'any', is_nullable = true},
+    },
+})
+log:create_index('primary', {parts = {'id'}, sequence = true})
+
+triggers[space_name] = space:on_replace(function(old, new)
+    box.space[log_name]:insert({box.NULL, old, new})
+end)
+
+return true
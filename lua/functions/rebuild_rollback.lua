@@ -0,0 +1,18 @@
+local space_name, shadow_name, old_name = ...
+
+if box.space[old_name] == nil then
+    error(string.format('space %q does not exist', old_name))
+end
+
+if box.space[shadow_name] ~= nil then
+    box.space[shadow_name]:drop()
+end
+
+box.atomic(function()
+    box.space[space_name]:rename(shadow_name)
+    box.space[old_name]:rename(space_name)
+end)
+
+box.space[shadow_name]:drop()
+
+return true
@@ -0,0 +1,27 @@
+local space_name, shadow_name, old_name, log_name = ...
+
+local log = box.space[log_name]
+if log ~= nil and log:len() > 0 then
+    return false
+end
+
+if box.space[old_name] ~= nil then
+    error(string.format('space %q already exists', old_name))
+end
+
+local triggers = rawget(_G, '__migrator_rebuild') or {}
+if triggers[space_name] ~= nil then
+    box.space[space_name]:on_replace(nil, triggers[space_name])
+    triggers[space_name] = nil
+end
+
+box.atomic(function()
+    box.space[space_name]:rename(old_name)
+    box.space[shadow_name]:rename(space_name)
+end)
+
+if log ~= nil then
+    log:drop()
+end
+
+return true
@@ -0,0 +1,36 @@
+local target = ...
+
+if target == nil then
+    target = {}
+    for id, lsn in pairs(box.info.vclock) do
+        if id ~= 0 then
+            table.insert(target, {id, lsn})
+        end
+    end
+end
+
+local lagging = {}
+
+for _, replica in pairs(box.info.replication) do
+    if replica.id ~= box.info.id then
+        local downstream = replica.downstream
+        local synced = downstream ~= nil and downstream.vclock ~= nil
+
+        if synced then
+            for _, pair in ipairs(target) do
+                if (downstream.vclock[pair[1]] or 0) < pair[2] then
+                    synced = false
+                    break
+                end
+            end
+        end
+
+        if not synced then
+            table.insert(lagging, replica.name or replica.uuid)
+        end
+    end
+end
+
+table.sort(lagging)
+
+return target, lagging
@@ -0,0 +1 @@
+return box.info.version
@@ -0,0 +1,24 @@
+local space_name, key, value, broadcast = ...
+
+if box.space[space_name] == nil then
+    box.schema.space.create(space_name, {
+        if_not_exists = true,
+        format = {
+            {name = 'key', type = 'string'},
+            {name = 'value', type = 'any'},
+        },
+    })
+    box.space[space_name]:create_index('primary', {parts = {'key'}, if_not_exists = true})
+end
+
+if value == nil then
+    box.space[space_name]:delete({key})
+else
+    box.space[space_name]:replace({key, value})
+end
+
+if broadcast and box.broadcast ~= nil then
+    box.broadcast(key, value)
+end
+
+return true
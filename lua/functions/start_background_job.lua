@@ -0,0 +1,69 @@
+local space_name, job_id, code, args, opposite_id = ...
+
+local fiber = require('fiber')
+
+if box.space[space_name] == nil then
+    box.schema.space.create(space_name, {
+        if_not_exists = true,
+        format = {
+            {name = 'id', type = 'string'},
+            {name = 'status', type = 'string'},
+            {name = 'error', type = 'string', is_nullable = true},
+            {name = 'started_at', type = 'number'},
+            {name = 'finished_at', type = 'number', is_nullable = true},
+        },
+    })
+    box.space[space_name]:create_index('primary', {parts = {'id'}, if_not_exists = true})
+end
+
+local space = box.space[space_name]
+
+-- the job of the other direction is stale once this one starts, e.g. migrate after rollback
+if opposite_id ~= nil then
+    space:delete(opposite_id)
+end
+
+-- the job has finished after the previous run stopped waiting for it, don't run it twice
+local row = space:get(job_id)
+if row ~= nil and row.status == 'done' then
+    return job_id
+end
+
+if rawget(_G, '__migrator_jobs') == nil then
+    rawset(_G, '__migrator_jobs', {})
+end
+
+local jobs = rawget(_G, '__migrator_jobs')
+
+-- the job started by previous interrupted run is still working, attach to it
+if jobs[job_id] ~= nil and jobs[job_id]:status() ~= 'dead' then
+    return job_id
+end
+
+local fn, err = loadstring(code)
+if fn == nil then
+    error(err)
+end
+
+space:replace({job_id, 'running', box.NULL, fiber.time(), box.NULL})
+
+local job = fiber.new(function()
+    local ok, job_err = pcall(fn, args)
+
+    if ok then
+        space:update(job_id, {{'=', 'status', 'done'}, {'=', 'finished_at', fiber.time()}})
+    else
+        space:update(job_id, {
+            {'=', 'status', 'failed'},
+            {'=', 'error', tostring(job_err)},
+            {'=', 'finished_at', fiber.time()},
+        })
+    end
+
+    -- cleared after the status is committed, so the status script never sees running job without fiber
+    jobs[job_id] = nil
+end)
+job:name('migrator:' .. job_id, {truncate = true})
+jobs[job_id] = job
+
+return job_id
@@ -0,0 +1,35 @@
+local journal_name, migration_id = ...
+
+local key_def = require('key_def')
+
+-- -1 means there is no journal: it was not recorded or was deleted by confirmation
+local journal = box.space[journal_name]
+if journal == nil or journal.index.migration:count({migration_id}) == 0 then
+    return -1
+end
+
+local restored = 0
+
+-- entries are applied from the latest one and deleted, so interrupted rollback can be repeated,
+-- marker entries with empty space are kept until the journal is cleared
+for _, entry in ipairs(journal.index.migration:select({migration_id}, {iterator = 'REQ'})) do
+    if entry.space ~= '' then
+        local space = box.space[entry.space]
+        if space == nil then
+            error(string.format('space %q does not exist', entry.space))
+        end
+
+        box.atomic(function()
+            if entry.old ~= nil then
+                space:replace(entry.old)
+            else
+                space:delete(key_def.new(space.index[0].parts):extract_key(entry.new))
+            end
+            journal:delete(entry.id)
+        end)
+
+        restored = restored + 1
+    end
+end
+
+return restored
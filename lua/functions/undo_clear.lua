@@ -0,0 +1,15 @@
+local journal_name, migration_id = ...
+
+local journal = box.space[journal_name]
+if journal == nil then
+    return 0
+end
+
+local cleared = 0
+
+for _, entry in ipairs(journal.index.migration:select({migration_id})) do
+    journal:delete(entry.id)
+    cleared = cleared + 1
+end
+
+return cleared
@@ -0,0 +1,55 @@
+local journal_name, migration_id, spaces = ...
+
+if box.space[journal_name] == nil then
+    box.schema.space.create(journal_name, {
+        if_not_exists = true,
+        format = {
+            {name = 'id', type = 'unsigned'},
+            {name = 'migration_id', type = 'string'},
+            {name = 'space', type = 'string'},
+            {name = 'old', type = 'any', is_nullable = true},
+            {name = 'new', type = 'any', is_nullable = true},
+        },
+    })
+    box.space[journal_name]:create_index('primary', {parts = {'id'}, sequence = true, if_not_exists = true})
+    box.space[journal_name]:create_index('migration', {parts = {'migration_id', 'id'}, if_not_exists = true})
+end
+
+if rawget(_G, '__migrator_undo') == nil then
+    rawset(_G, '__migrator_undo', {})
+end
+
+-- triggers left by the process died in the middle of the migration are replaced
+local registry = rawget(_G, '__migrator_undo')
+if registry[migration_id] ~= nil then
+    for space_name, trigger in pairs(registry[migration_id]) do
+        if box.space[space_name] ~= nil then
+            pcall(box.space[space_name].on_replace, box.space[space_name], nil, trigger)
+        end
+    end
+    registry[migration_id] = nil
+end
+
+for _, space_name in ipairs(spaces) do
+    if box.space[space_name] == nil then
+        error(string.format('space %q does not exist', space_name))
+    end
+end
+
+-- the marker entry with empty space distinguishes the recorded journal from the confirmed one
+box.space[journal_name]:insert({box.NULL, migration_id, '', box.NULL, box.NULL})
+
+-- only writes of the migrator connection session are recorded, concurrent application writes are not
+local session_id = box.session.id()
+
+local triggers = {}
+for _, space_name in ipairs(spaces) do
+    triggers[space_name] = box.space[space_name]:on_replace(function(old, new)
+        if box.session.id() == session_id then
+            box.space[journal_name]:insert({box.NULL, migration_id, space_name, old, new})
+        end
+    end)
+end
+registry[migration_id] = triggers
+
+return true
@@ -0,0 +1,17 @@
+local migration_id = ...
+
+local registry = rawget(_G, '__migrator_undo') or {}
+local triggers = registry[migration_id]
+
+if triggers == nil then
+    return false
+end
+
+for space_name, trigger in pairs(triggers) do
+    if box.space[space_name] ~= nil then
+        pcall(box.space[space_name].on_replace, box.space[space_name], nil, trigger)
+    end
+end
+registry[migration_id] = nil
+
+return true
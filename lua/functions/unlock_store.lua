@@ -0,0 +1,14 @@
+local space_name, key, owner = ...
+
+if box.space[space_name] == nil then
+    return false
+end
+
+local current = box.space[space_name]:get(key)
+if current == nil or current.value.owner ~= owner then
+    return false
+end
+
+box.space[space_name]:delete(key)
+
+return true
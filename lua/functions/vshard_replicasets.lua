@@ -0,0 +1,9 @@
+local ids = {}
+
+for id in pairs(vshard.router.routeall()) do
+    table.insert(ids, id)
+end
+
+table.sort(ids)
+
+return ids
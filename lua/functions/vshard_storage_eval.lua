@@ -0,0 +1,12 @@
+local replicaset_id, expr, args, timeout = ...
+
+local replicaset = vshard.router.routeall()[replicaset_id]
+if replicaset == nil then
+    error(string.format('replicaset "%s" is not found', replicaset_id))
+end
+
+if replicaset.master == nil or replicaset.master.conn == nil then
+    error(string.format('replicaset "%s" has no master', replicaset_id))
+end
+
+return replicaset.master.conn:eval(expr, args, {timeout = timeout})
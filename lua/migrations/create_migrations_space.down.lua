@@ -1 +1,5 @@
-box.schema.drop_space('_migrations_space_')
\ No newline at end of file
+local space_name = ...
+
+if box.space[space_name] ~= nil then
+    box.space[space_name]:drop()
+end
@@ -1,6 +1,8 @@
-box.schema.create_space('_migrations_space_', { if_not_exists = true, format={
+local space_name = ...
+
+box.schema.create_space(space_name, { if_not_exists = true, format={
     {'id',type='string'},
     {'executed_at',type='datetime'},
 }})
 
-box.space._migrations_space_:create_index('id', {parts = {'id'}, if_not_exists = true, unique = true})
\ No newline at end of file
+box.space[space_name]:create_index('id', {parts = {'id'}, if_not_exists = true, unique = true})
@@ -0,0 +1 @@
+box.schema.drop_space('test-1')
\ No newline at end of file
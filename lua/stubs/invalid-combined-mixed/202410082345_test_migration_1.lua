@@ -0,0 +1,2 @@
+-- +migrate Up
+box.schema.create_space('test-1')
@@ -0,0 +1,4 @@
+-- +migrate Up
+box.schema.create_space('test-1')
+-- +migrate Up
+box.schema.create_space('test-2')
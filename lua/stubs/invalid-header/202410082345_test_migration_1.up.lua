@@ -0,0 +1,2 @@
+-- @timeout forever
+box.schema.create_space('test-1')
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- description: create test space
+-- depends: 202410082345_test_migration_0,
+box.schema.create_space('test-1')
@@ -0,0 +1,6 @@
+-- description: create test space
+-- +migrate Up
+box.schema.create_space('test-1')
+
+-- +migrate Down
+box.schema.drop_space('test-1')
@@ -0,0 +1,2 @@
+-- destructive: true
+box.space['test-1']:truncate()
\ No newline at end of file
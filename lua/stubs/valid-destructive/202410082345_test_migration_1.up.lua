@@ -0,0 +1 @@
+box.space['test-1']:truncate()
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- @see https://www.tarantool.io/en/doc/latest/reference/reference_lua/box_schema/space_drop/
+-- @timeout 1m
+-- @read_mode any
+-- @maintenance true
+-- @transactional false
+box.schema.drop_space('test-1')
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- @timeout 5m
+-- @write_mode prefer_rw
+-- @transactional true
+-- @confirm true
+box.schema.create_space('test-1')
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- description: create test space
+-- author: John Doe
+-- tags: test, ddl
+-- transactional: false
+box.schema.create_space('test-1')
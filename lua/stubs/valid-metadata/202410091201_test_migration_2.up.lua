@@ -0,0 +1,4 @@
+-- description: drop test space
+-- depends: 202410082345_test_migration_1
+-- irreversible: true
+box.schema.drop_space('test-1')
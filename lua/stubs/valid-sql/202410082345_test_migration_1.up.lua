@@ -0,0 +1 @@
+box.schema.create_space('test-1')
@@ -0,0 +1,4 @@
+-- description: create users table
+CREATE TABLE users (id INTEGER PRIMARY KEY, name STRING);
+/* index on name; */
+CREATE INDEX users_name ON users (name);
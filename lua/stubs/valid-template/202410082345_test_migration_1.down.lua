@@ -0,0 +1 @@
+box.schema.drop_space('users')
@@ -1,8 +1,25 @@
 package tarantool_migrator
 
 import (
+	"context"
 	"embed"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
 )
 
 //go:embed lua
 var LuaFs embed.FS
+
+// evalLuaScript evaluates embedded lua script in Options.WriteMode and ignores its result.
+func evalLuaScript(ctx context.Context, tt pool.Pooler, opts Options, path string, args ...any) error {
+	data, err := LuaFs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	_, err = tt.Do(tarantool.NewEvalRequest(string(data)).Context(ctx).Args(args), opts.WriteMode).Get()
+
+	return err
+}
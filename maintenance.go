@@ -0,0 +1,62 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// DefaultStateSpace is the space where the migrator keeps its state, e.g. maintenance mode.
+const DefaultStateSpace = "_migrator_state"
+
+// DefaultMaintenanceKey is the state key and box.broadcast key of maintenance mode.
+const DefaultMaintenanceKey = "tarantool_migrator.maintenance"
+
+// MaintenanceState is stored while a migration declaring Maintenance is running.
+type MaintenanceState struct {
+	// ID is the ID of the running migration
+	ID string `msgpack:"id" json:"id"`
+	// Direction is MigrationDirectionUp or MigrationDirectionDown
+	Direction string `msgpack:"direction" json:"direction"`
+}
+
+// CheckMaintenance returns the maintenance state or nil when maintenance mode is off.
+// Applications can call it before writes, or watch opts.MaintenanceKey with Pooler.NewWatcher.
+func CheckMaintenance(ctx context.Context, tt pool.Pooler, opts Options) (*MaintenanceState, error) {
+	state, err := getMigratorState[MaintenanceState](ctx, tt, opts, opts.ReadMode, opts.MaintenanceKey)
+	if err != nil {
+		return nil, fmt.Errorf("check maintenance: %w", err)
+	}
+
+	return state, nil
+}
+
+// withMaintenance runs fn with maintenance mode enabled when the migration declares it.
+// Maintenance mode is cleared even if fn fails or the context is canceled.
+func (m *Migrator) withMaintenance(ctx context.Context, migration *Migration, direction string,
+	fn func() error) (err error) {
+	if !migration.Maintenance || m.opts.DryRun {
+		return fn()
+	}
+
+	state := &MaintenanceState{ID: migration.ID, Direction: direction}
+	if err = m.ex.setState(ctx, m.opts.MaintenanceKey, state); err != nil {
+		return fmt.Errorf("enable maintenance: %w", err)
+	}
+
+	m.logger.InfoContext(ctx, "maintenance mode enabled", "id", migration.ID)
+
+	defer func() {
+		if clearErr := m.ex.setState(context.WithoutCancel(ctx), m.opts.MaintenanceKey, nil); clearErr != nil {
+			err = errors.Join(err, fmt.Errorf("disable maintenance: %w", clearErr))
+
+			return
+		}
+
+		m.logger.InfoContext(ctx, "maintenance mode disabled", "id", migration.ID)
+	}()
+
+	return fn()
+}
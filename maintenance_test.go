@@ -0,0 +1,140 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type MaintenanceTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *MaintenanceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+
+	opts := DefaultOptions
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), Maintenance: true},
+	}, WithLogger(SilentLogger), WithOptions(&opts))
+}
+
+func (suite *MaintenanceTestSuite) stateArgs(call int) any {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[call].Req, calls[call].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec.Args
+}
+
+func (suite *MaintenanceTestSuite) TestMigrateEnablesAndDisablesMaintenance() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 6)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultMaintenanceKey,
+		map[string]any{"id": "migration-1", "direction": "up"}, true}, suite.stateArgs(2))
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultMaintenanceKey, nil, true}, suite.stateArgs(5))
+}
+
+func (suite *MaintenanceTestSuite) TestMigrateDisablesMaintenanceOnError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-1" error: user migrate: eval lua: tarantool error`, err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 5)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultMaintenanceKey, nil, true}, suite.stateArgs(4))
+}
+
+func (suite *MaintenanceTestSuite) TestMigrateDisableMaintenanceError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.doer.AddResponseError(fmt.Errorf("connection lost"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), "migration \"migration-1\" error: user migrate: eval lua: tarantool error\n"+
+		"disable maintenance: set migrator state: connection lost", err.Error())
+}
+
+func (suite *MaintenanceTestSuite) TestMigrateEnableMaintenanceError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-1" error: enable maintenance: set migrator state: tarantool error`,
+		err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+}
+
+func (suite *MaintenanceTestSuite) TestMigrateWithoutMaintenance() {
+	suite.testable.migrations[0].Maintenance = false
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+}
+
+func (suite *MaintenanceTestSuite) TestCheckMaintenanceEnabled() {
+	suite.doer.AddResponseRaw([]interface{}{map[string]any{"id": "migration-1", "direction": "up"}})
+
+	state, err := CheckMaintenance(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &MaintenanceState{ID: "migration-1", Direction: MigrationDirectionUp}, state)
+
+	calls := suite.mock.DoCalls()
+	assert.Equal(suite.T(), pool.ModeAny, calls[0].Mode)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultMaintenanceKey}, suite.stateArgs(0))
+}
+
+func (suite *MaintenanceTestSuite) TestCheckMaintenanceDisabled() {
+	suite.doer.AddResponseRaw([]interface{}{nil})
+
+	state, err := CheckMaintenance(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), state)
+}
+
+func (suite *MaintenanceTestSuite) TestCheckMaintenanceError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	state, err := CheckMaintenance(suite.ctx, suite.mock, DefaultOptions)
+	assert.Nil(suite.T(), state)
+	assert.Equal(suite.T(), "check maintenance: get migrator state: tarantool error", err.Error())
+}
+
+func TestMaintenanceTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceTestSuite))
+}
@@ -3,6 +3,8 @@ package tarantool_migrator
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/tarantool/go-tarantool/v3"
 	"github.com/tarantool/go-tarantool/v3/pool"
 )
@@ -21,6 +23,41 @@ type Migration struct {
 	Migrate MigrateFunc
 	// Rollback will be executed on rollback. Can be nil.
 	Rollback RollbackFunc
+	// Description is a human-readable description of migration.
+	Description string
+	// Author of migration.
+	Author string
+	// Depends lists IDs of migrations which must be applied before this one.
+	Depends []string
+	// Tags are arbitrary labels of migration.
+	Tags []string
+	// Irreversible marks migration which can't be rolled back.
+	Irreversible bool
+	// Timeout limits execution time of Migrate and Rollback. Zero means no limit.
+	Timeout time.Duration
+	// ReadMode overrides Options.ReadMode for this migration. Can be nil.
+	ReadMode *pool.Mode
+	// WriteMode overrides Options.WriteMode for this migration. Can be nil.
+	WriteMode *pool.Mode
+	// Transactional overrides Options.Transactional for this migration. Can be nil.
+	Transactional *bool
+	// RequiresConfirmation marks migration which must be confirmed before running (see WithConfirmFunc).
+	RequiresConfirmation bool
+	// Maintenance enables maintenance mode while the migration is running (see CheckMaintenance).
+	Maintenance bool
+	// MinTarantoolVersion is the minimal server version required by the migration, e.g. "2.11". Can be empty.
+	MinTarantoolVersion string
+	// MaxTarantoolVersion is the maximal server version supported by the migration. Can be empty.
+	MaxTarantoolVersion string
+	// UndoSpaces are spaces recorded into the undo journal while Migrate is running.
+	// When Rollback is nil, the journal is used to restore them (see NewUndoRollbackFunction).
+	UndoSpaces []string
+	// BackupSpaces are copied into a local file before Migrate is running (see Options.BackupDir).
+	BackupSpaces []string
+	// Destructive contains destructive calls found in the up migration file by the loader.
+	Destructive []DestructiveCall
+	// AllowDestructive acknowledges Destructive calls of this migration (see Options.AllowDestructive).
+	AllowDestructive bool
 }
 
 func (mg *Migration) isValidForMigrate() error {
@@ -40,16 +77,95 @@ func (mg *Migration) isValidForRollback() error {
 		return ErrMissingID
 	}
 
-	if mg.Rollback == nil {
+	if mg.Irreversible {
+		return ErrIrreversibleMigration
+	}
+
+	if mg.Rollback == nil && len(mg.UndoSpaces) == 0 {
 		return ErrMissingRollbackFunc
 	}
 
 	return nil
 }
 
+// rollbackFunc returns Rollback or restores the undo journal when Rollback is nil.
+func (mg *Migration) rollbackFunc() RollbackFunc {
+	if mg.Rollback == nil {
+		return NewUndoRollbackFunction()
+	}
+
+	return mg.Rollback
+}
+
+// checkTarantoolVersion returns ErrTarantoolVersionMismatch when the server version is out of declared bounds.
+func (mg *Migration) checkTarantoolVersion(server tarantoolVersion) error {
+	if mg.MinTarantoolVersion != "" {
+		required, err := parseTarantoolVersion(mg.MinTarantoolVersion)
+		if err != nil {
+			return err
+		}
+
+		if server.less(required) {
+			return fmt.Errorf("%w: %s < %s", ErrTarantoolVersionMismatch, server, required)
+		}
+	}
+
+	if mg.MaxTarantoolVersion != "" {
+		supported, err := parseTarantoolVersion(mg.MaxTarantoolVersion)
+		if err != nil {
+			return err
+		}
+
+		if supported.less(server) {
+			return fmt.Errorf("%w: %s > %s", ErrTarantoolVersionMismatch, server, supported)
+		}
+	}
+
+	return nil
+}
+
+// hasTarantoolVersionBounds returns true when the migration declares min or max server version.
+func (mg *Migration) hasTarantoolVersionBounds() bool {
+	return mg.MinTarantoolVersion != "" || mg.MaxTarantoolVersion != ""
+}
+
+// options returns global options with migration overrides applied.
+func (mg *Migration) options(opts Options) Options {
+	if mg.ReadMode != nil {
+		opts.ReadMode = *mg.ReadMode
+	}
+
+	if mg.WriteMode != nil {
+		opts.WriteMode = *mg.WriteMode
+	}
+
+	if mg.Transactional != nil {
+		opts.Transactional = *mg.Transactional
+	}
+
+	return opts
+}
+
+// context derives context limited by migration timeout.
+func (mg *Migration) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if mg.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, mg.Timeout)
+}
+
+// NewGenericMigrateFunction evaluates lua code, MigrationArgs are passed as the first argument (...).
 func NewGenericMigrateFunction(req string) func(context.Context, pool.Pooler, Options) error {
 	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
-		_, err := tt.Do(tarantool.NewEvalRequest(req).Context(ctx), opts.WriteMode).Get()
+		expr := req
+		if opts.Transactional {
+			expr = wrapLuaTransaction(req)
+		}
+
+		args := []any{newMigrationArgs(ctx, opts)}
+
+		_, err := tt.Do(tarantool.NewEvalRequest(expr).Context(ctx).Args(args), opts.WriteMode).Get()
 		if err != nil {
 			return fmt.Errorf("eval lua: %w", err)
 		}
@@ -57,3 +173,24 @@ func NewGenericMigrateFunction(req string) func(context.Context, pool.Pooler, Op
 		return nil
 	}
 }
+
+// NewSQLMigrateFunction executes SQL statements one by one with execute requests.
+func NewSQLMigrateFunction(statements []string) func(context.Context, pool.Pooler, Options) error {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		for i, stmt := range statements {
+			_, err := tt.Do(tarantool.NewExecuteRequest(stmt).Context(ctx), opts.WriteMode).Get()
+			if err != nil {
+				return fmt.Errorf("execute sql statement %d: %w", i+1, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func wrapLuaTransaction(req string) string {
+	return "box.begin()\n" +
+		"local ok, err = pcall(function(...)\n" + req + "\nend, ...)\n" +
+		"if not ok then box.rollback() error(err) end\n" +
+		"box.commit()"
+}
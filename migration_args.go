@@ -0,0 +1,45 @@
+package tarantool_migrator
+
+import "context"
+
+const MigrationDirectionUp = "up"
+const MigrationDirectionDown = "down"
+
+// MigrationArgs is passed to lua migrations as the first eval argument:
+//
+//	local args = ...
+//	box.space[args.migrations_space]:len()
+type MigrationArgs struct {
+	// MigrationsSpace is the name of migrations space
+	MigrationsSpace string `msgpack:"migrations_space"`
+	// ID of running migration
+	ID string `msgpack:"id"`
+	// Direction is MigrationDirectionUp or MigrationDirectionDown
+	Direction string `msgpack:"direction"`
+	// Values are user-supplied values from Options.LuaArgs
+	Values map[string]any `msgpack:"values"`
+}
+
+type migrationArgsKey struct{}
+
+func contextWithMigrationArgs(ctx context.Context, migration *Migration, direction string) context.Context {
+	return context.WithValue(ctx, migrationArgsKey{}, migrationArgs{id: migration.ID, direction: direction})
+}
+
+// newMigrationArgs builds args of the migration running with context ctx.
+// When the function is called outside the migrator, ID and direction are empty.
+func newMigrationArgs(ctx context.Context, opts Options) MigrationArgs {
+	args := MigrationArgs{MigrationsSpace: opts.MigrationsSpace, Values: opts.LuaArgs}
+
+	if v, ok := ctx.Value(migrationArgsKey{}).(migrationArgs); ok {
+		args.ID = v.id
+		args.Direction = v.direction
+	}
+
+	return args
+}
+
+type migrationArgs struct {
+	id        string
+	direction string
+}
@@ -0,0 +1,64 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type MigrationArgsTestSuite struct {
+	suite.Suite
+	opts Options
+}
+
+func (suite *MigrationArgsTestSuite) SetupTest() {
+	suite.opts = DefaultOptions
+	suite.opts.LuaArgs = map[string]any{"engine": "vinyl"}
+}
+
+func (suite *MigrationArgsTestSuite) TestNewMigrationArgsWithoutMigration() {
+	args := newMigrationArgs(context.Background(), suite.opts)
+	assert.Equal(suite.T(), MigrationArgs{
+		MigrationsSpace: "migrations",
+		Values:          map[string]any{"engine": "vinyl"},
+	}, args)
+}
+
+func (suite *MigrationArgsTestSuite) TestNewMigrationArgsWithMigration() {
+	ctx := contextWithMigrationArgs(context.Background(), &Migration{ID: "test"}, MigrationDirectionDown)
+	args := newMigrationArgs(ctx, suite.opts)
+	assert.Equal(suite.T(), MigrationArgs{
+		MigrationsSpace: "migrations",
+		ID:              "test",
+		Direction:       "down",
+		Values:          map[string]any{"engine": "vinyl"},
+	}, args)
+}
+
+func (suite *MigrationArgsTestSuite) TestGenericMigrateFunctionPassesArgs() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([]interface{}{})
+	recorder := NewRecordingPooler(&mocks.PoolerMock{DoFunc: func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}})
+
+	ctx := contextWithMigrationArgs(context.Background(), &Migration{ID: "test"}, MigrationDirectionUp)
+	err := NewGenericMigrateFunction("local args = ...")(ctx, recorder, suite.opts)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{map[string]any{
+		"migrations_space": "migrations",
+		"id":               "test",
+		"direction":        "up",
+		"values":           map[string]any{"engine": "vinyl"},
+	}}, recorder.Requests()[0].Args)
+}
+
+func TestMigrationArgsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationArgsTestSuite))
+}
@@ -2,17 +2,24 @@ package tarantool_migrator
 
 import (
 	"io/fs"
+	"path"
 	"strings"
 )
 
 const MigrationFileSuffixUp = "up"
 const MigrationFileSuffixDown = "down"
 const MigrationFilePrefixExcluded = "--"
+const MigrationFileExtLua = ".lua"
+const MigrationFileExtSQL = ".sql"
+const MigrationFileExtTemplate = ".tmpl"
 
 type MigrationFile struct {
-	path string
-	name string
-	cmd  string
+	path   string
+	name   string
+	cmd    string
+	ext    string
+	tmpl   bool
+	header *migrationHeader
 }
 
 func (mf *MigrationFile) GetPath() string {
@@ -27,29 +34,117 @@ func (mf *MigrationFile) GetCmd() string {
 	return mf.cmd
 }
 
-func NewMigrationFile(path string, file fs.DirEntry) (*MigrationFile, error) {
+// GetExt returns file extension: MigrationFileExtLua or MigrationFileExtSQL.
+func (mf *MigrationFile) GetExt() string {
+	return mf.ext
+}
+
+// IsTemplate returns true for migration file rendered with text/template before use.
+func (mf *MigrationFile) IsTemplate() bool {
+	return mf.tmpl
+}
+
+// IsCombined returns true for single-file migration with Up and Down sections.
+func (mf *MigrationFile) IsCombined() bool {
+	return mf.cmd == ""
+}
+
+// GetDescription returns "description" declared in file header.
+func (mf *MigrationFile) GetDescription() string {
+	return mf.header.description
+}
+
+// GetAuthor returns "author" declared in file header.
+func (mf *MigrationFile) GetAuthor() string {
+	return mf.header.author
+}
+
+// GetDepends returns "depends" declared in file header.
+func (mf *MigrationFile) GetDepends() []string {
+	return mf.header.depends
+}
+
+// GetTags returns "tags" declared in file header.
+func (mf *MigrationFile) GetTags() []string {
+	return mf.header.tags
+}
+
+// GetTransactional returns "transactional" declared in file header or nil.
+func (mf *MigrationFile) GetTransactional() *bool {
+	return mf.header.transactional
+}
+
+// IsIrreversible returns "irreversible" declared in file header.
+func (mf *MigrationFile) IsIrreversible() bool {
+	return mf.header.irreversible
+}
+
+// IsMaintenance returns "maintenance" declared in file header.
+func (mf *MigrationFile) IsMaintenance() bool {
+	return mf.header.maintenance
+}
+
+// IsBackground returns "background" declared in file header.
+func (mf *MigrationFile) IsBackground() bool {
+	return mf.header.background
+}
+
+// ParseHeader parses header comment block of the file contents.
+// Lua and SQL files share "--" line comments, so header format is the same.
+func (mf *MigrationFile) ParseHeader(data []byte) error {
+	header, err := parseMigrationHeader(string(data))
+	if err != nil {
+		return err
+	}
+
+	mf.header = header
+
+	return nil
+}
+
+// apply copies header into migration, settings of Migrate are ignored in the down file header.
+func (mf *MigrationFile) apply(migration *Migration) {
+	if mf.GetCmd() == MigrationFileSuffixDown {
+		mf.header.applyMetadata(migration)
+
+		return
+	}
+
+	mf.header.apply(migration)
+}
+
+func NewMigrationFile(dir string, file fs.DirEntry) (*MigrationFile, error) {
 	fileName := file.Name()
-	if !strings.HasSuffix(fileName, ".lua") {
+	tmpl := strings.HasSuffix(fileName, MigrationFileExtTemplate)
+
+	ext := path.Ext(strings.TrimSuffix(fileName, MigrationFileExtTemplate))
+	if ext != MigrationFileExtLua && ext != MigrationFileExtSQL {
 		return nil, ErrWrongMigrationFileFormat
 	}
 
-	baseName := strings.TrimSuffix(fileName, ".lua")
+	baseName := strings.TrimSuffix(strings.TrimSuffix(fileName, MigrationFileExtTemplate), ext)
+
+	name, cmd := baseName, ""
 
 	lastDot := strings.LastIndex(baseName, ".")
-	if lastDot < 0 {
-		return nil, ErrWrongMigrationFileFormat
+	if lastDot >= 0 {
+		name, cmd = baseName[:lastDot], baseName[lastDot+1:]
 	}
 
-	name := baseName[:lastDot]
-	cmd := baseName[lastDot+1:]
+	if name == "" {
+		return nil, ErrWrongMigrationFileFormat
+	}
 
-	if cmd != MigrationFileSuffixUp && cmd != MigrationFileSuffixDown {
+	if lastDot >= 0 && cmd != MigrationFileSuffixUp && cmd != MigrationFileSuffixDown {
 		return nil, ErrWrongMigrationCmdFormat
 	}
 
 	return &MigrationFile{
-		path: path + "/" + fileName,
-		name: name,
-		cmd:  cmd,
+		path:   dir + "/" + fileName,
+		name:   name,
+		cmd:    cmd,
+		ext:    ext,
+		tmpl:   tmpl,
+		header: &migrationHeader{declared: make(map[string]bool)},
 	}, nil
 }
@@ -59,6 +59,49 @@ func (suite *MigrationFileTestSuite) TestNewMigrationFileInvalidMigrationFileCmd
 	assert.Equal(suite.T(), "wrong migration cmd format", err.Error())
 }
 
+func (suite *MigrationFileTestSuite) TestParseHeader() {
+	files, _ := LuaFs.ReadDir("lua/stubs/valid-metadata")
+	result, _ := NewMigrationFile("lua/stubs/valid-metadata", files[0])
+	data, _ := LuaFs.ReadFile(result.GetPath())
+	err := result.ParseHeader(data)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "create test space", result.GetDescription())
+	assert.Equal(suite.T(), "John Doe", result.GetAuthor())
+	assert.Empty(suite.T(), result.GetDepends())
+	assert.Equal(suite.T(), []string{"test", "ddl"}, result.GetTags())
+	assert.False(suite.T(), *result.GetTransactional())
+	assert.False(suite.T(), result.IsIrreversible())
+}
+
+func (suite *MigrationFileTestSuite) TestParseHeaderInvalid() {
+	files, _ := LuaFs.ReadDir("lua/stubs/invalid-metadata")
+	result, _ := NewMigrationFile("lua/stubs/invalid-metadata", files[0])
+	err := result.ParseHeader([]byte("-- irreversible: maybe"))
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrWrongMigrationHeader)
+	assert.False(suite.T(), result.IsIrreversible())
+}
+
+func (suite *MigrationFileTestSuite) TestNewMigrationFileCombinedValid() {
+	files, _ := LuaFs.ReadDir("lua/stubs/valid-combined")
+	result, err := NewMigrationFile("lua/stubs/valid-combined", files[0])
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "202410082345_test_migration_1", result.GetName())
+	assert.Equal(suite.T(), "", result.GetCmd())
+	assert.True(suite.T(), result.IsCombined())
+	assert.Equal(suite.T(), "lua/stubs/valid-combined/202410082345_test_migration_1.lua", result.GetPath())
+}
+
+func (suite *MigrationFileTestSuite) TestNewMigrationFileSQLValid() {
+	files, _ := LuaFs.ReadDir("lua/stubs/valid-sql")
+	result, err := NewMigrationFile("lua/stubs/valid-sql", files[1])
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "202410091201_test_migration_2", result.GetName())
+	assert.Equal(suite.T(), "down", result.GetCmd())
+	assert.Equal(suite.T(), ".sql", result.GetExt())
+	assert.Equal(suite.T(), "lua/stubs/valid-sql/202410091201_test_migration_2.down.sql", result.GetPath())
+}
+
 func TestMigrationFileTestSuite(t *testing.T) {
 	suite.Run(t, new(MigrationFileTestSuite))
 }
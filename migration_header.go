@@ -0,0 +1,304 @@
+package tarantool_migrator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const migrationHeaderComment = "--"
+const migrationHeaderDirectivePrefix = "@"
+
+// migrationHeaderField matches "key: value" header comments.
+var migrationHeaderField = regexp.MustCompile(`^([a-z_]+):\s*(.*)$`)
+
+// migrationHeaderFields are keys which can be declared as "-- key: value".
+var migrationHeaderFields = map[string]bool{
+	"description":   true,
+	"author":        true,
+	"depends":       true,
+	"tags":          true,
+	"transactional": true,
+	"irreversible":  true,
+	"maintenance":   true,
+	"destructive":   true,
+}
+
+// migrationHeaderDirectives are keys which can be declared as "-- @key value".
+// Other "@" names are treated as plain comments, e.g. "-- @see https://...".
+var migrationHeaderDirectives = map[string]bool{
+	"description":           true,
+	"author":                true,
+	"depends":               true,
+	"tags":                  true,
+	"irreversible":          true,
+	"timeout":               true,
+	"read_mode":             true,
+	"write_mode":            true,
+	"transactional":         true,
+	"confirm":               true,
+	"maintenance":           true,
+	"min_tarantool_version": true,
+	"max_tarantool_version": true,
+	"background":            true,
+	"undo":                  true,
+	"backup":                true,
+	"destructive":           true,
+}
+
+// migrationHeader contains directives declared in the leading comment block of lua migration file.
+//
+//	-- description: create users space
+//	-- author: John Doe
+//	-- depends: 202410082345_test_migration_1
+//	-- tags: users, ddl
+//	-- @timeout 5m
+//	-- @write_mode rw
+//	-- @transactional true
+//	-- @maintenance true
+//	-- @min_tarantool_version 2.11
+//	-- @max_tarantool_version 3.2
+//	-- @background true
+//	-- @undo users, orders
+//	-- @backup users
+//	-- destructive: true
+type migrationHeader struct {
+	description   string
+	author        string
+	depends       []string
+	tags          []string
+	irreversible  bool
+	timeout       time.Duration
+	readMode      *pool.Mode
+	writeMode     *pool.Mode
+	transactional *bool
+	confirm       bool
+	maintenance   bool
+	minVersion    string
+	maxVersion    string
+	background    bool
+	undo          []string
+	backup        []string
+	destructive   bool
+	declared      map[string]bool
+}
+
+func (h *migrationHeader) apply(mg *Migration) {
+	h.applyMetadata(mg)
+	h.applySettings(mg)
+}
+
+// applyMetadata copies descriptive fields, they can be declared in any migration file.
+func (h *migrationHeader) applyMetadata(mg *Migration) {
+	if h.description != "" {
+		mg.Description = h.description
+	}
+
+	if h.author != "" {
+		mg.Author = h.author
+	}
+
+	if len(h.depends) > 0 {
+		mg.Depends = h.depends
+	}
+
+	if len(h.tags) > 0 {
+		mg.Tags = h.tags
+	}
+
+	if h.irreversible {
+		mg.Irreversible = true
+	}
+}
+
+// applySettings copies fields which control running of Migrate, they are taken from up file only.
+func (h *migrationHeader) applySettings(mg *Migration) {
+	if h.timeout > 0 {
+		mg.Timeout = h.timeout
+	}
+
+	if h.readMode != nil {
+		mg.ReadMode = h.readMode
+	}
+
+	if h.writeMode != nil {
+		mg.WriteMode = h.writeMode
+	}
+
+	if h.transactional != nil {
+		mg.Transactional = h.transactional
+	}
+
+	if h.confirm {
+		mg.RequiresConfirmation = true
+	}
+
+	if h.maintenance {
+		mg.Maintenance = true
+	}
+
+	if h.minVersion != "" {
+		mg.MinTarantoolVersion = h.minVersion
+	}
+
+	if h.maxVersion != "" {
+		mg.MaxTarantoolVersion = h.maxVersion
+	}
+
+	if len(h.undo) > 0 {
+		mg.UndoSpaces = h.undo
+	}
+
+	if len(h.backup) > 0 {
+		mg.BackupSpaces = h.backup
+	}
+
+	if h.destructive {
+		mg.AllowDestructive = true
+	}
+}
+
+func (h *migrationHeader) set(name, value string) error {
+	if h.declared[name] {
+		return fmt.Errorf("duplicate directive %q", name)
+	}
+
+	h.declared[name] = true
+
+	var err error
+
+	switch name {
+	case "description":
+		h.description, err = parseHeaderString(value)
+	case "author":
+		h.author, err = parseHeaderString(value)
+	case "depends":
+		h.depends, err = parseHeaderList(value)
+	case "tags":
+		h.tags, err = parseHeaderList(value)
+	case "irreversible":
+		h.irreversible, err = strconv.ParseBool(value)
+	case "timeout":
+		h.timeout, err = time.ParseDuration(value)
+	case "read_mode":
+		var mode pool.Mode
+		mode, err = parsePoolMode(value)
+		h.readMode = &mode
+	case "write_mode":
+		var mode pool.Mode
+		mode, err = parsePoolMode(value)
+		h.writeMode = &mode
+	case "transactional":
+		var tx bool
+		tx, err = strconv.ParseBool(value)
+		h.transactional = &tx
+	case "confirm":
+		h.confirm, err = strconv.ParseBool(value)
+	case "maintenance":
+		h.maintenance, err = strconv.ParseBool(value)
+	case "min_tarantool_version":
+		h.minVersion, err = parseHeaderVersion(value)
+	case "max_tarantool_version":
+		h.maxVersion, err = parseHeaderVersion(value)
+	case "background":
+		h.background, err = strconv.ParseBool(value)
+	case "undo":
+		h.undo, err = parseHeaderList(value)
+	case "backup":
+		h.backup, err = parseHeaderList(value)
+	case "destructive":
+		h.destructive, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("unknown directive %q", name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("directive %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// parseMigrationHeader parses directives from comment lines at the top of the file.
+// Directives are written as "-- @name value" or "-- name: value".
+// Parsing stops on the first line which is not a comment.
+func parseMigrationHeader(data string) (*migrationHeader, error) {
+	header := &migrationHeader{declared: make(map[string]bool)}
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, migrationHeaderComment) {
+			break
+		}
+
+		name, value, ok := parseHeaderDirective(strings.TrimSpace(strings.TrimPrefix(line, migrationHeaderComment)))
+		if !ok {
+			continue
+		}
+
+		if err := header.set(name, value); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrWrongMigrationHeader, i+1, err)
+		}
+	}
+
+	return header, nil
+}
+
+func parseHeaderDirective(comment string) (string, string, bool) {
+	if strings.HasPrefix(comment, migrationHeaderDirectivePrefix) {
+		name, value, _ := strings.Cut(strings.TrimPrefix(comment, migrationHeaderDirectivePrefix), " ")
+		if !migrationHeaderDirectives[name] {
+			return "", "", false
+		}
+
+		return name, strings.TrimSpace(value), true
+	}
+
+	match := migrationHeaderField.FindStringSubmatch(comment)
+	if match == nil || !migrationHeaderFields[match[1]] {
+		return "", "", false
+	}
+
+	return match[1], strings.TrimSpace(match[2]), true
+}
+
+func parseHeaderString(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("empty value")
+	}
+
+	return value, nil
+}
+
+func parseHeaderVersion(value string) (string, error) {
+	if _, err := parseTarantoolVersion(value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+func parseHeaderList(value string) ([]string, error) {
+	if value == "" {
+		return nil, errors.New("empty value")
+	}
+
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+		if items[i] == "" {
+			return nil, fmt.Errorf("empty item in list %q", value)
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,129 @@
+package tarantool_migrator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+type MigrationHeaderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderValid() {
+	header, err := parseMigrationHeader("-- @timeout 5m\n-- @read_mode ro\n-- @write_mode prefer_rw\n" +
+		"-- some comment\n-- @transactional true\n-- @confirm true\n-- maintenance: true\nbox.info()")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.Equal(suite.T(), 5*time.Minute, migration.Timeout)
+	assert.Equal(suite.T(), pool.ModeRO, *migration.ReadMode)
+	assert.Equal(suite.T(), pool.ModePreferRW, *migration.WriteMode)
+	assert.True(suite.T(), *migration.Transactional)
+	assert.True(suite.T(), migration.RequiresConfirmation)
+	assert.True(suite.T(), migration.Maintenance)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderTarantoolVersion() {
+	header, err := parseMigrationHeader("-- @min_tarantool_version 2.11\n-- @max_tarantool_version 3.2.1\nbox.info()")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.Equal(suite.T(), "2.11", migration.MinTarantoolVersion)
+	assert.Equal(suite.T(), "3.2.1", migration.MaxTarantoolVersion)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderWrongTarantoolVersion() {
+	header, err := parseMigrationHeader("-- @min_tarantool_version latest")
+	assert.Nil(suite.T(), header)
+	assert.Equal(suite.T(), `wrong migration header: line 1: directive "min_tarantool_version": `+
+		`wrong tarantool version: "latest"`, err.Error())
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderBackground() {
+	header, err := parseMigrationHeader("-- @background true\nbox.info()")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), header.background)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderUndo() {
+	header, err := parseMigrationHeader("-- @undo users, orders\nbox.info()")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.Equal(suite.T(), []string{"users", "orders"}, migration.UndoSpaces)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderDestructive() {
+	header, err := parseMigrationHeader("-- destructive: true\nbox.space.users:drop()")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.True(suite.T(), migration.AllowDestructive)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderStopsOnCode() {
+	header, err := parseMigrationHeader("box.info()\n-- @timeout 5m")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.Zero(suite.T(), migration.Timeout)
+	assert.Nil(suite.T(), migration.WriteMode)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderUnknownDirective() {
+	header, err := parseMigrationHeader("\n-- @see https://example.com/docs\n-- @foo bar\n-- @timeout 5m\nbox.info()")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5*time.Minute, header.timeout)
+	assert.Equal(suite.T(), map[string]bool{"timeout": true}, header.declared)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderWrongValue() {
+	header, err := parseMigrationHeader("-- @write_mode master")
+	assert.Nil(suite.T(), header)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration header: line 1: directive "write_mode": unknown pool mode "master"`,
+		err.Error())
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderMetadata() {
+	header, err := parseMigrationHeader("-- description: create users space\n-- author: John Doe\n" +
+		"-- Note: regular comment\n-- depends: m1, m2\n-- tags: users,ddl\n-- irreversible: true\n" +
+		"-- transactional: false\nbox.info()")
+	assert.NoError(suite.T(), err)
+
+	migration := &Migration{ID: "test"}
+	header.apply(migration)
+	assert.Equal(suite.T(), "create users space", migration.Description)
+	assert.Equal(suite.T(), "John Doe", migration.Author)
+	assert.Equal(suite.T(), []string{"m1", "m2"}, migration.Depends)
+	assert.Equal(suite.T(), []string{"users", "ddl"}, migration.Tags)
+	assert.True(suite.T(), migration.Irreversible)
+	assert.False(suite.T(), *migration.Transactional)
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderDuplicateDirective() {
+	header, err := parseMigrationHeader("-- transactional: true\n-- @transactional false")
+	assert.Nil(suite.T(), header)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration header: line 2: duplicate directive "transactional"`, err.Error())
+}
+
+func (suite *MigrationHeaderTestSuite) TestParseMigrationHeaderEmptyValue() {
+	header, err := parseMigrationHeader("-- author:")
+	assert.Nil(suite.T(), header)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration header: line 1: directive "author": empty value`, err.Error())
+}
+
+func TestMigrationHeaderTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationHeaderTestSuite))
+}
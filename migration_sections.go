@@ -0,0 +1,64 @@
+package tarantool_migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const MigrationSectionMarkerUp = "-- +migrate Up"
+const MigrationSectionMarkerDown = "-- +migrate Down"
+
+// parseMigrationSections splits single-file migration into Up and Down sections.
+// Only comments are allowed before the first section marker. Down section is optional.
+func parseMigrationSections(data string) (string, string, error) {
+	sections := make(map[string]*strings.Builder)
+
+	var current *strings.Builder
+
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == MigrationSectionMarkerUp || trimmed == MigrationSectionMarkerDown {
+			cmd := MigrationFileSuffixUp
+			if trimmed == MigrationSectionMarkerDown {
+				cmd = MigrationFileSuffixDown
+			}
+
+			if _, ok := sections[cmd]; ok {
+				return "", "", fmt.Errorf("%w: line %d: duplicate %q section", ErrWrongMigrationSections, i+1, cmd)
+			}
+
+			current = &strings.Builder{}
+			sections[cmd] = current
+
+			continue
+		}
+
+		if current == nil {
+			if trimmed != "" && !strings.HasPrefix(trimmed, migrationHeaderComment) {
+				return "", "", fmt.Errorf("%w: line %d: statement before first section", ErrWrongMigrationSections, i+1)
+			}
+
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+
+	up, ok := sections[MigrationFileSuffixUp]
+	if !ok || strings.TrimSpace(up.String()) == "" {
+		return "", "", fmt.Errorf("%w: missing %q section", ErrWrongMigrationSections, MigrationFileSuffixUp)
+	}
+
+	down, ok := sections[MigrationFileSuffixDown]
+	if !ok {
+		return up.String(), "", nil
+	}
+
+	if strings.TrimSpace(down.String()) == "" {
+		return "", "", fmt.Errorf("%w: empty %q section", ErrWrongMigrationSections, MigrationFileSuffixDown)
+	}
+
+	return up.String(), down.String(), nil
+}
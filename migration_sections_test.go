@@ -0,0 +1,65 @@
+package tarantool_migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationSectionsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsValid() {
+	up, down, err := parseMigrationSections("-- header\n-- +migrate Up\nbox.schema.create_space('test-1')\n" +
+		"-- +migrate Down\nbox.schema.drop_space('test-1')")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "box.schema.create_space('test-1')\n", up)
+	assert.Equal(suite.T(), "box.schema.drop_space('test-1')\n", down)
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsDownFirst() {
+	up, down, err := parseMigrationSections("-- +migrate Down\nbox.schema.drop_space('test-1')\n" +
+		"-- +migrate Up\nbox.schema.create_space('test-1')")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "box.schema.create_space('test-1')\n", up)
+	assert.Equal(suite.T(), "box.schema.drop_space('test-1')\n", down)
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsWithoutDown() {
+	up, down, err := parseMigrationSections("-- +migrate Up\nbox.schema.create_space('test-1')")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "box.schema.create_space('test-1')\n", up)
+	assert.Empty(suite.T(), down)
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsDuplicate() {
+	_, _, err := parseMigrationSections("-- +migrate Up\nbox.info()\n-- +migrate Down\nbox.info()\n" +
+		"-- +migrate Down\nbox.info()")
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrWrongMigrationSections)
+	assert.Equal(suite.T(), `wrong migration sections: line 5: duplicate "down" section`, err.Error())
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsMissingUp() {
+	_, _, err := parseMigrationSections("-- +migrate Down\nbox.info()")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration sections: missing "up" section`, err.Error())
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsEmptyDown() {
+	_, _, err := parseMigrationSections("-- +migrate Up\nbox.info()\n-- +migrate Down\n\n")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration sections: empty "down" section`, err.Error())
+}
+
+func (suite *MigrationSectionsTestSuite) TestParseMigrationSectionsStatementBeforeSection() {
+	_, _, err := parseMigrationSections("box.info()\n-- +migrate Up\nbox.info()")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration sections: line 1: statement before first section`, err.Error())
+}
+
+func TestMigrationSectionsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationSectionsTestSuite))
+}
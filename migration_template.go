@@ -0,0 +1,101 @@
+package tarantool_migrator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is the data passed to templated migrations (*.tmpl files).
+type TemplateData struct {
+	// ID of rendered migration
+	ID string
+	// MigrationsSpace is the name of migrations space
+	MigrationsSpace string
+	// Options are the loader options
+	Options Options
+	// Container is Options.MigrationsContainer
+	Container map[string]any
+}
+
+// templateDataWithoutOptions is passed to templated migrations when the loader has no options,
+// so templates using them fail with ErrTemplateOptionsNotSet instead of rendering DefaultOptions.
+type templateDataWithoutOptions struct {
+	// ID of rendered migration
+	ID string
+}
+
+func (templateDataWithoutOptions) MigrationsSpace() (string, error) {
+	return "", ErrTemplateOptionsNotSet
+}
+
+func (templateDataWithoutOptions) Options() (Options, error) {
+	return Options{}, ErrTemplateOptionsNotSet
+}
+
+func (templateDataWithoutOptions) Container() (map[string]any, error) {
+	return nil, ErrTemplateOptionsNotSet
+}
+
+// DefaultTemplateFuncs are helper functions available in templated migrations.
+var DefaultTemplateFuncs = template.FuncMap{
+	// lua_string quotes value as lua string literal
+	"lua_string": func(v any) string {
+		return luaQuote(fmt.Sprint(v))
+	},
+	// sql_string quotes value as sql string literal
+	"sql_string": func(v any) string {
+		return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+	},
+	// sql_ident quotes value as sql identifier
+	"sql_ident": func(v any) string {
+		return `"` + strings.ReplaceAll(fmt.Sprint(v), `"`, `""`) + `"`
+	},
+}
+
+// luaQuote returns lua string literal: backslash and quote are escaped, control bytes are written as "\ddd".
+// Other bytes are kept as is, lua strings are byte strings, so utf-8 needs no escaping.
+func luaQuote(s string) string {
+	var b strings.Builder
+
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+
+	for i := range len(s) {
+		switch c := s[i]; {
+		case c == '\\' || c == '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// renderMigrationTemplate renders the text with TemplateData or templateDataWithoutOptions.
+func renderMigrationTemplate(name, text string, data any, funcs template.FuncMap) ([]byte, error) {
+	tmpl := template.New(name).Option("missingkey=error").Funcs(DefaultTemplateFuncs)
+	if funcs != nil {
+		tmpl = tmpl.Funcs(funcs)
+	}
+
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWrongMigrationTemplate, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWrongMigrationTemplate, err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,63 @@
+package tarantool_migrator
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationTemplateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigrationTemplateTestSuite) TestRenderMigrationTemplate() {
+	data := TemplateData{ID: "test", MigrationsSpace: "migrations", Container: map[string]any{"engine": "vinyl"}}
+	result, err := renderMigrationTemplate("test", "{{ .ID }} {{ .MigrationsSpace }} {{ .Container.engine }} "+
+		"{{ lua_string \"a\\\"b\" }} {{ sql_string \"it's\" }} {{ sql_ident \"t\" }} {{ upper \"x\" }}", data,
+		template.FuncMap{"upper": func(s string) string { return "X" }})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `test migrations vinyl "a\"b" 'it''s' "t" X`, string(result))
+}
+
+func (suite *MigrationTemplateTestSuite) TestLuaQuote() {
+	assert.Equal(suite.T(), `"a\"b\\c"`, luaQuote(`a"b\c`))
+	assert.Equal(suite.T(), `"line\0101\009tab\000\127"`, luaQuote("line\n1\ttab\x00\x7f"))
+	assert.Equal(suite.T(), `"пользователи 🙂"`, luaQuote("пользователи 🙂"))
+	assert.Equal(suite.T(), "\"\xff\"", luaQuote("\xff"))
+}
+
+func (suite *MigrationTemplateTestSuite) TestRenderMigrationTemplateParseError() {
+	result, err := renderMigrationTemplate("test", "{{ .ID ", TemplateData{}, nil)
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrWrongMigrationTemplate)
+}
+
+func (suite *MigrationTemplateTestSuite) TestRenderMigrationTemplateMissingKey() {
+	result, err := renderMigrationTemplate("test", "{{ .Container.engine }}", TemplateData{
+		Container: map[string]any{},
+	}, nil)
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `wrong migration template: template: test:1:13: executing "test" at <.Container.engine>: `+
+		`map has no entry for key "engine"`, err.Error())
+}
+
+func (suite *MigrationTemplateTestSuite) TestRenderMigrationTemplateWithoutOptions() {
+	result, err := renderMigrationTemplate("test", "{{ .ID }}", templateDataWithoutOptions{ID: "test"}, nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "test", string(result))
+
+	for _, text := range []string{"{{ .MigrationsSpace }}", "{{ .Options.StateSpace }}", "{{ .Container.engine }}"} {
+		result, err = renderMigrationTemplate("test", text, templateDataWithoutOptions{ID: "test"}, nil)
+		assert.Nil(suite.T(), result, text)
+		assert.ErrorIs(suite.T(), err, ErrWrongMigrationTemplate, text)
+		assert.ErrorIs(suite.T(), err, ErrTemplateOptionsNotSet, text)
+	}
+}
+
+func TestMigrationTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationTemplateTestSuite))
+}
@@ -1,9 +1,17 @@
 package tarantool_migrator
 
 import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kachit/tarantool-migrator/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"testing"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
 )
 
 type MigrationTestSuite struct {
@@ -53,6 +61,111 @@ func (suite *MigrationTestSuite) TestIsValidForRollbackInvalid() {
 	assert.Equal(suite.T(), "missing rollback function in migration", err.Error())
 }
 
+func (suite *MigrationTestSuite) TestIsValidForRollbackIrreversible() {
+	suite.testable.ID = "test-5"
+	suite.testable.Rollback = NewGenericMigrateFunction("foo")
+	suite.testable.Irreversible = true
+	err := suite.testable.isValidForRollback()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "migration is irreversible", err.Error())
+}
+
+func (suite *MigrationTestSuite) TestOptionsWithoutOverrides() {
+	opts := suite.testable.options(DefaultOptions)
+	assert.Equal(suite.T(), DefaultOptions, opts)
+}
+
+func (suite *MigrationTestSuite) TestOptionsWithOverrides() {
+	readMode := pool.ModeRO
+	writeMode := pool.ModePreferRW
+	tx := true
+	suite.testable.ReadMode = &readMode
+	suite.testable.WriteMode = &writeMode
+	suite.testable.Transactional = &tx
+
+	opts := suite.testable.options(DefaultOptions)
+	assert.Equal(suite.T(), pool.ModeRO, opts.ReadMode)
+	assert.Equal(suite.T(), pool.ModePreferRW, opts.WriteMode)
+	assert.True(suite.T(), opts.Transactional)
+	assert.Equal(suite.T(), pool.ModeRW, DefaultOptions.WriteMode)
+}
+
+func (suite *MigrationTestSuite) TestCheckTarantoolVersion() {
+	migration := &Migration{ID: "test", MinTarantoolVersion: "2.10", MaxTarantoolVersion: "2.11.3"}
+	assert.False(suite.T(), (&Migration{ID: "test"}).hasTarantoolVersionBounds())
+	assert.True(suite.T(), migration.hasTarantoolVersionBounds())
+
+	assert.NoError(suite.T(), migration.checkTarantoolVersion(tarantoolVersion{2, 11, 3}))
+
+	err := migration.checkTarantoolVersion(tarantoolVersion{2, 8, 4})
+	assert.ErrorIs(suite.T(), err, ErrTarantoolVersionMismatch)
+	assert.Equal(suite.T(), "tarantool version does not satisfy migration: 2.8.4 < 2.10.0", err.Error())
+
+	err = migration.checkTarantoolVersion(tarantoolVersion{3, 0, 0})
+	assert.Equal(suite.T(), "tarantool version does not satisfy migration: 3.0.0 > 2.11.3", err.Error())
+
+	migration.MaxTarantoolVersion = "3.x"
+	err = migration.checkTarantoolVersion(tarantoolVersion{3, 0, 0})
+	assert.ErrorIs(suite.T(), err, ErrWrongTarantoolVersion)
+}
+
+func (suite *MigrationTestSuite) TestContextWithoutTimeout() {
+	ctx, cancel := suite.testable.context(context.Background())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(suite.T(), ok)
+}
+
+func (suite *MigrationTestSuite) TestContextWithTimeout() {
+	suite.testable.Timeout = time.Minute
+	ctx, cancel := suite.testable.context(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(suite.T(), ok)
+	assert.WithinDuration(suite.T(), time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func (suite *MigrationTestSuite) TestWrapLuaTransaction() {
+	expr := wrapLuaTransaction("box.info()")
+	assert.Equal(suite.T(), "box.begin()\nlocal ok, err = pcall(function(...)\nbox.info()\nend, ...)\n"+
+		"if not ok then box.rollback() error(err) end\nbox.commit()", expr)
+}
+
+func (suite *MigrationTestSuite) TestSQLMigrateFunctionSuccess() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([]interface{}{})
+	mockDoer.AddResponseRaw([]interface{}{})
+	mock := &mocks.PoolerMock{DoFunc: func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}}
+	recorder := NewRecordingPooler(mock)
+
+	fn := NewSQLMigrateFunction([]string{"CREATE TABLE t (id INTEGER PRIMARY KEY)", "DROP TABLE t"})
+	err := fn(context.Background(), recorder, DefaultOptions)
+	requests := recorder.Requests()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 2)
+	assert.Equal(suite.T(), "IPROTO_EXECUTE", requests[0].Type)
+	assert.Equal(suite.T(), "rw", requests[0].Mode)
+	assert.Equal(suite.T(), "CREATE TABLE t (id INTEGER PRIMARY KEY)", requests[0].SQL)
+	assert.Equal(suite.T(), "DROP TABLE t", requests[1].SQL)
+}
+
+func (suite *MigrationTestSuite) TestSQLMigrateFunctionError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([]interface{}{})
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	mock := &mocks.PoolerMock{DoFunc: func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}}
+
+	fn := NewSQLMigrateFunction([]string{"SELECT 1", "SELECT 2", "SELECT 3"})
+	err := fn(context.Background(), mock, DefaultOptions)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "execute sql statement 2: tarantool error", err.Error())
+	assert.Len(suite.T(), mock.DoCalls(), 2)
+}
+
 func TestMigrationTestSuite(t *testing.T) {
 	suite.Run(t, new(MigrationTestSuite))
 }
@@ -18,6 +18,10 @@ func (m *migrationTuple) ToSlice() []any {
 	}
 }
 
+func (m *migrationTuple) applied() AppliedMigration {
+	return AppliedMigration{ID: m.ID, ExecutedAt: m.ExecutedAt.ToTime()}
+}
+
 func newMigrationTuple(migrationID string) *migrationTuple {
 	dt, _ := datetime.NewDatetime(time.Now().UTC())
 
@@ -0,0 +1,85 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultStatusPollInterval = time.Second
+
+// MissingMigrationsError is returned when migrations are not applied yet.
+type MissingMigrationsError struct {
+	// IDs contains missing migrations in definition order
+	IDs []string
+}
+
+func (e *MissingMigrationsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingMigrations.Error(), strings.Join(e.IDs, ", "))
+}
+
+func (e *MissingMigrationsError) Unwrap() error {
+	return ErrMissingMigrations
+}
+
+// CheckUpToDate returns MissingMigrationsError when some defined migrations are not applied.
+// Nothing is written, so it is safe to call on application startup.
+func (m *Migrator) CheckUpToDate(ctx context.Context) error {
+	return m.checkApplied(ctx, m.migrations.ids())
+}
+
+// WaitUntilApplied blocks until migrations with given IDs, or all defined migrations when no IDs are given,
+// appear in the migrations space. Status is polled every Options.StatusPollInterval.
+// The last MissingMigrationsError or status error is returned when the context is done.
+func (m *Migrator) WaitUntilApplied(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		ids = m.migrations.ids()
+	}
+
+	interval := m.opts.StatusPollInterval
+	if interval <= 0 {
+		interval = defaultStatusPollInterval
+	}
+
+	for {
+		err := m.checkApplied(ctx, ids)
+		if err == nil {
+			return nil
+		}
+
+		m.logger.DebugContext(ctx, "waiting for migrations", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait until applied: %w: %w", ctx.Err(), err)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (m *Migrator) checkApplied(ctx context.Context, ids []string) error {
+	records, err := m.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf(`migrations status error: %w`, err)
+	}
+
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.ID] = true
+	}
+
+	var missing []string
+
+	for _, id := range ids {
+		if !applied[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingMigrationsError{IDs: missing}
+	}
+
+	return nil
+}
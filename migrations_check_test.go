@@ -0,0 +1,107 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type MigrationsCheckTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *MigrationsCheckTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1"},
+		{ID: "migration-2"},
+		{ID: "migration-3"},
+	}, WithLogger(SilentLogger), WithOptions(&Options{
+		MigrationsSpace:    "migrations",
+		ReadMode:           pool.ModeAny,
+		WriteMode:          pool.ModeRW,
+		StatusPollInterval: time.Millisecond,
+	}))
+}
+
+func (suite *MigrationsCheckTestSuite) addAppliedResponse(ids ...string) {
+	tuples := make([][]interface{}, 0, len(ids))
+	for _, id := range ids {
+		body := newMigrationTupleStubResponseBody()
+		body[0][0] = id
+		tuples = append(tuples, body[0])
+	}
+
+	suite.doer.AddResponseRaw([][][]interface{}{tuples})
+}
+
+func (suite *MigrationsCheckTestSuite) TestCheckUpToDateSuccess() {
+	suite.addAppliedResponse("migration-1", "migration-2", "migration-3")
+
+	err := suite.testable.CheckUpToDate(suite.ctx)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *MigrationsCheckTestSuite) TestCheckUpToDateMissing() {
+	suite.addAppliedResponse("migration-2")
+
+	err := suite.testable.CheckUpToDate(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrMissingMigrations)
+	assert.Equal(suite.T(), "migrations are not applied: migration-1, migration-3", err.Error())
+
+	var missing *MissingMigrationsError
+	assert.True(suite.T(), errors.As(err, &missing))
+	assert.Equal(suite.T(), []string{"migration-1", "migration-3"}, missing.IDs)
+}
+
+func (suite *MigrationsCheckTestSuite) TestCheckUpToDateError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.CheckUpToDate(suite.ctx)
+	assert.Equal(suite.T(), "migrations status error: list applied migrations: tarantool error", err.Error())
+}
+
+func (suite *MigrationsCheckTestSuite) TestWaitUntilAppliedPolls() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.addAppliedResponse()
+	suite.addAppliedResponse("migration-1")
+
+	err := suite.testable.WaitUntilApplied(suite.ctx, "migration-1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+}
+
+func (suite *MigrationsCheckTestSuite) TestWaitUntilAppliedContextDone() {
+	for range 1000 {
+		suite.addAppliedResponse("migration-1")
+	}
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err := suite.testable.WaitUntilApplied(ctx)
+	assert.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+	assert.ErrorIs(suite.T(), err, ErrMissingMigrations)
+}
+
+func TestMigrationsCheckTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsCheckTestSuite))
+}
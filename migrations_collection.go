@@ -1,6 +1,9 @@
 package tarantool_migrator
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
 
 type MigrationsCollection []*Migration
 
@@ -18,6 +21,32 @@ func (m *MigrationsCollection) Find(migrationID string) (*Migration, error) {
 	return nil, ErrMigrationIDDoesNotExist
 }
 
+func (m *MigrationsCollection) ids() []string {
+	ids := make([]string, 0, len(*m))
+	for _, mgr := range *m {
+		ids = append(ids, mgr.ID)
+	}
+
+	return ids
+}
+
+// validateDependencies checks that every dependency is defined before the migration depending on it.
+func (m *MigrationsCollection) validateDependencies() error {
+	defined := make(map[string]bool, len(*m))
+
+	for _, mgr := range *m {
+		for _, dep := range mgr.Depends {
+			if !defined[dep] {
+				return fmt.Errorf(`migration "%s" error: %w: "%s"`, mgr.ID, ErrMigrationDependencyNotFound, dep)
+			}
+		}
+
+		defined[mgr.ID] = true
+	}
+
+	return nil
+}
+
 func (m *MigrationsCollection) sort() {
 	mm := *m
 	sort.Slice(mm, func(i, j int) bool {
@@ -56,6 +56,22 @@ func (suite *MigrationsCollectionTestSuite) TestSort() {
 	assert.Equal(suite.T(), suite.testable[2].ID, "202410091545_test_migration_3")
 }
 
+func (suite *MigrationsCollectionTestSuite) TestValidateDependenciesValid() {
+	suite.testable = append(suite.testable, &Migration{ID: "test-1"})
+	suite.testable = append(suite.testable, &Migration{ID: "test-2", Depends: []string{"test-1"}})
+	assert.NoError(suite.T(), suite.testable.validateDependencies())
+}
+
+func (suite *MigrationsCollectionTestSuite) TestValidateDependenciesInvalid() {
+	suite.testable = append(suite.testable, &Migration{ID: "test-1", Depends: []string{"test-2"}})
+	suite.testable = append(suite.testable, &Migration{ID: "test-2"})
+	err := suite.testable.validateDependencies()
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrMigrationDependencyNotFound)
+	assert.Equal(suite.T(), `migration "test-1" error: migration dependency is not defined before migration: "test-2"`,
+		err.Error())
+}
+
 func TestMigrationsCollectionTestSuite(t *testing.T) {
 	suite.Run(t, new(MigrationsCollectionTestSuite))
 }
@@ -0,0 +1,39 @@
+package tarantool_migrator
+
+// MigrationsStatus describes applied and pending migrations of an instance.
+type MigrationsStatus struct {
+	// Applied contains IDs of migrations stored in migrations space
+	Applied []string
+	// Pending contains IDs of defined migrations which are not applied yet
+	Pending []string
+	// InProgress contains checkpoints of partially completed resumable data migrations
+	InProgress []MigrationCheckpoint
+}
+
+// IsUpToDate returns true when there are no pending migrations.
+func (s *MigrationsStatus) IsUpToDate() bool {
+	return len(s.Pending) == 0
+}
+
+func newMigrationsStatus(migrations MigrationsCollection, applied []AppliedMigration,
+	checkpoints []MigrationCheckpoint) *MigrationsStatus {
+	status := &MigrationsStatus{
+		Applied:    make([]string, 0, len(applied)),
+		Pending:    make([]string, 0),
+		InProgress: checkpoints,
+	}
+	appliedIDs := make(map[string]bool, len(applied))
+
+	for _, record := range applied {
+		status.Applied = append(status.Applied, record.ID)
+		appliedIDs[record.ID] = true
+	}
+
+	for _, migration := range migrations {
+		if !appliedIDs[migration.ID] {
+			status.Pending = append(status.Pending, migration.ID)
+		}
+	}
+
+	return status
+}
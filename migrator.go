@@ -2,6 +2,7 @@ package tarantool_migrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -21,96 +22,363 @@ func NewMigrator(tt pool.Pooler, migrations MigrationsCollection, options ...fun
 		opt(m)
 	}
 
-	m.ex = newExecutor(tt, m.opts)
+	m.tt = tt
+	if m.store == nil {
+		m.store = newTarantoolStore(tt, m.opts)
+	}
+
+	m.ex = newExecutor(tt, m.opts, m.store)
 
 	return m
 }
 
 type Migrator struct {
+	tt         pool.Pooler
 	ex         executor
+	store      Store
 	opts       *Options
 	logger     *slog.Logger
 	migrations MigrationsCollection
+	confirm    ConfirmFunc
+	checks     []PreflightCheck
+}
+
+// migratorRun is the state of a single Migrate or RollbackLast call, so the Migrator itself stays stateless.
+type migratorRun struct {
+	// result of the run
+	result *Result
+	// serverVersions are fetched once per run for every write mode
+	serverVersions map[pool.Mode]tarantoolVersion
+	// skipped are IDs of migrations skipped by VersionPolicySkip
+	skipped map[string]bool
+}
+
+func (r *migratorRun) setServerVersion(mode pool.Mode, version tarantoolVersion) {
+	if r.serverVersions == nil {
+		r.serverVersions = make(map[pool.Mode]tarantoolVersion)
+	}
+
+	r.serverVersions[mode] = version
 }
 
+func (r *migratorRun) skip(id string) {
+	if r.skipped == nil {
+		r.skipped = make(map[string]bool)
+	}
+
+	r.skipped[id] = true
+}
+
+// ConfirmFunc is the func signature for confirmation of migrations marked with RequiresConfirmation.
+type ConfirmFunc func(ctx context.Context, migration *Migration) (bool, error)
+
 func (m *Migrator) Migrate(ctx context.Context) error {
+	_, err := m.MigrateWithResult(ctx)
+
+	return err
+}
+
+// MigrateWithResult applies pending migrations like Migrate and describes what happened to every migration.
+// The result is returned on error too.
+func (m *Migrator) MigrateWithResult(ctx context.Context) (*Result, error) {
+	run := &migratorRun{result: newResult(MigrationDirectionUp)}
+	err := m.runMigrate(ctx, run)
+	run.result.finish(err)
+
+	return run.result, err
+}
+
+func (m *Migrator) runMigrate(ctx context.Context, run *migratorRun) error {
 	m.logger.DebugContext(ctx, "started migrate command", "count", len(m.migrations), "options", m.opts)
 
 	if m.migrations.IsEmpty() {
 		return ErrNoDefinedMigrations
 	}
 
-	err := m.ex.createMigrationsSpaceIfNotExists(ctx, createMigrationsSpacePath)
+	err := m.migrations.validateDependencies()
+	if err != nil {
+		return err
+	}
+
+	err = m.preflight(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(ctx, func(ctx context.Context) error {
+		return m.migrateAll(ctx, run)
+	})
+}
+
+// migrateAll applies pending migrations in order of the collection.
+func (m *Migrator) migrateAll(ctx context.Context, run *migratorRun) error {
+	err := m.store.Init(ctx)
 	if err != nil {
 		return fmt.Errorf(`init migrations space error: %w`, err)
 	}
 
+	err = m.checkDestructive(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, migration := range m.migrations {
 		m.logger.InfoContext(ctx, "migration process started", "id", migration.ID)
 
-		err = migration.isValidForMigrate()
-		if err != nil {
-			return fmt.Errorf(`migration "%s" error: %w`, migration.ID, err)
-		}
+		startedAt := time.Now().UTC()
+		action, err := m.migrateOne(ctx, run, migration)
+		run.result.add(migration.ID, action, startedAt, err)
 
-		exists, err := m.ex.hasAppliedMigration(ctx, migration.ID)
 		if err != nil {
 			return fmt.Errorf(`migration "%s" error: %w`, migration.ID, err)
 		}
+	}
 
-		if !exists {
-			startedAt := time.Now().UTC()
+	batch := run.result.ids(MigrationActionApplied, MigrationActionDryRun)
+	if len(batch) > 0 && m.opts.WaitReplication == ReplicationWaitRun {
+		return m.waitReplication(ctx)
+	}
 
-			err = m.ex.applyMigration(ctx, migration)
-			if err != nil {
-				return fmt.Errorf(`migration "%s" error: %w`, migration.ID, err)
-			}
+	return nil
+}
 
-			migratedAt := time.Now().UTC().Sub(startedAt)
-			m.logger.InfoContext(ctx, "migration successfully migrated",
-				"id", migration.ID, "duration_ms", formatDurationToMs(migratedAt))
-		} else {
-			m.logger.InfoContext(ctx, "migration is already migrated", "id", migration.ID)
-		}
+// migrateOne checks the migration and applies it when it is pending.
+func (m *Migrator) migrateOne(ctx context.Context, run *migratorRun,
+	migration *Migration) (MigrationAction, error) {
+	err := migration.isValidForMigrate()
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	applied, err := m.store.Get(ctx, migration.ID)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	if applied != nil {
+		m.logger.InfoContext(ctx, "migration is already migrated", "id", migration.ID)
+
+		return MigrationActionAlreadyApplied, nil
+	}
+
+	skip, err := m.skipByTarantoolVersion(ctx, run, migration)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	if skip {
+		return MigrationActionSkipped, nil
+	}
+
+	batch := append(run.result.ids(MigrationActionApplied, MigrationActionDryRun), migration.ID)
+
+	err = m.migrate(ctx, migration, batch)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	if m.opts.DryRun {
+		return MigrationActionDryRun, nil
+	}
+
+	return MigrationActionApplied, nil
+}
+
+// migrate applies the pending migration and runs after-migration hooks.
+func (m *Migrator) migrate(ctx context.Context, migration *Migration, batch []string) error {
+	err := m.confirmMigration(ctx, migration)
+	if err != nil {
+		return err
+	}
+
+	if err = m.backup(ctx, migration); err != nil {
+		return err
+	}
+
+	startedAt := time.Now().UTC()
+
+	err = m.withMaintenance(ctx, migration, MigrationDirectionUp, func() error {
+		return m.ex.applyMigration(contextWithLogger(ctx, m.logger.With("id", migration.ID)), migration)
+	})
+	if err != nil {
+		return err
+	}
+
+	migratedAt := time.Now().UTC().Sub(startedAt)
+	m.logger.InfoContext(ctx, "migration successfully migrated",
+		"id", migration.ID, "duration_ms", formatDurationToMs(migratedAt))
+
+	m.storeSchemaVersion(ctx, migration.ID, MigrationDirectionUp, batch)
+
+	if m.opts.WaitReplication == ReplicationWaitEachMigration {
+		return m.waitReplication(ctx)
 	}
 
 	return nil
 }
 
 func (m *Migrator) RollbackLast(ctx context.Context) error {
+	_, err := m.RollbackLastWithResult(ctx)
+
+	return err
+}
+
+// RollbackLastWithResult rolls back the last applied migration like RollbackLast and describes the outcome.
+// The result is returned on error too.
+func (m *Migrator) RollbackLastWithResult(ctx context.Context) (*Result, error) {
+	run := &migratorRun{result: newResult(MigrationDirectionDown)}
+	err := m.runRollbackLast(ctx, run)
+	run.result.finish(err)
+
+	return run.result, err
+}
+
+func (m *Migrator) runRollbackLast(ctx context.Context, run *migratorRun) error {
 	m.logger.DebugContext(ctx, "started rollback-last command", "count", len(m.migrations), "options", m.opts)
 
 	if m.migrations.IsEmpty() {
 		return ErrNoDefinedMigrations
 	}
 
-	mgr, err := m.ex.findLastAppliedMigration(ctx)
+	err := m.preflight(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(ctx, func(ctx context.Context) error {
+		return m.rollbackLast(ctx, run)
+	})
+}
+
+// rollbackLast rolls back the last applied migration.
+func (m *Migrator) rollbackLast(ctx context.Context, run *migratorRun) error {
+	mgr, err := m.store.Last(ctx)
 	if err != nil {
 		return fmt.Errorf(`find applied migration error: %w`, err)
 	}
 
 	m.logger.InfoContext(ctx, "migration found for rollback", "id", mgr.ID)
 
-	migration, err := m.migrations.Find(mgr.ID)
+	startedAt := time.Now().UTC()
+	action, err := m.rollbackOne(ctx, run, mgr.ID)
+	run.result.add(mgr.ID, action, startedAt, err)
+
 	if err != nil {
 		return fmt.Errorf(`migration "%s" error: %w`, mgr.ID, err)
 	}
 
+	return nil
+}
+
+// rollbackOne checks the applied migration and rolls it back.
+func (m *Migrator) rollbackOne(ctx context.Context, run *migratorRun, id string) (MigrationAction, error) {
+	migration, err := m.migrations.Find(id)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
 	err = migration.isValidForRollback()
 	if err != nil {
-		return fmt.Errorf(`migration "%s" error: %w`, mgr.ID, err)
+		return MigrationActionFailed, err
+	}
+
+	err = m.checkTarantoolVersion(ctx, run, migration)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	err = m.rollback(ctx, migration)
+	if err != nil {
+		return MigrationActionFailed, err
+	}
+
+	if m.opts.DryRun {
+		return MigrationActionDryRun, nil
+	}
+
+	return MigrationActionRolledBack, nil
+}
+
+// rollback rolls back the applied migration and runs after-rollback hooks.
+func (m *Migrator) rollback(ctx context.Context, migration *Migration) error {
+	err := m.confirmMigration(ctx, migration)
+	if err != nil {
+		return err
 	}
 
 	startedAt := time.Now().UTC()
 
-	err = m.ex.rollbackMigration(ctx, migration)
+	err = m.withMaintenance(ctx, migration, MigrationDirectionDown, func() error {
+		return m.ex.rollbackMigration(contextWithLogger(ctx, m.logger.With("id", migration.ID)), migration)
+	})
 	if err != nil {
-		return fmt.Errorf(`migration "%s" error: %w`, mgr.ID, err)
+		return err
 	}
 
 	rolledAt := time.Now().UTC().Sub(startedAt)
 	m.logger.InfoContext(ctx, "migration successfully rolled back",
-		"id", mgr.ID, "duration_ms", formatDurationToMs(rolledAt))
+		"id", migration.ID, "duration_ms", formatDurationToMs(rolledAt))
+
+	m.storeSchemaVersion(ctx, migration.ID, MigrationDirectionDown, []string{migration.ID})
+
+	if m.opts.WaitReplication != ReplicationWaitNone {
+		return m.waitReplication(ctx)
+	}
+
+	return nil
+}
+
+// Status compares defined migrations with migrations space without writing anything.
+func (m *Migrator) Status(ctx context.Context) (*MigrationsStatus, error) {
+	applied, err := m.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`migrations status error: %w`, err)
+	}
+
+	checkpoints, err := m.ex.listCheckpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`migrations status error: %w`, err)
+	}
+
+	return newMigrationsStatus(m.migrations, applied, checkpoints), nil
+}
+
+// withLock runs fn holding the store lock when Options.Lock is set.
+func (m *Migrator) withLock(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if !m.opts.Lock || m.opts.DryRun {
+		return fn(ctx)
+	}
+
+	if err = m.store.Lock(ctx); err != nil {
+		return fmt.Errorf(`lock migrations store error: %w`, err)
+	}
+
+	defer func() {
+		if unlockErr := m.store.Unlock(context.WithoutCancel(ctx)); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf(`unlock migrations store error: %w`, unlockErr))
+		}
+	}()
+
+	return fn(ctx)
+}
+
+func (m *Migrator) confirmMigration(ctx context.Context, migration *Migration) error {
+	if !migration.RequiresConfirmation || m.opts.DryRun {
+		return nil
+	}
+
+	if m.confirm == nil {
+		return ErrMigrationNotConfirmed
+	}
+
+	confirmed, err := m.confirm(ctx, migration)
+	if err != nil {
+		return fmt.Errorf("confirm migration: %w", err)
+	}
+
+	if !confirmed {
+		return ErrMigrationNotConfirmed
+	}
 
 	return nil
 }
@@ -126,3 +394,16 @@ func WithOptions(op *Options) func(migrator *Migrator) {
 		m.opts = op
 	}
 }
+
+func WithConfirmFunc(fn ConfirmFunc) func(migrator *Migrator) {
+	return func(m *Migrator) {
+		m.confirm = fn
+	}
+}
+
+// WithStore sets the store of applied migrations, TarantoolStore on the migrated pool is used by default.
+func WithStore(store Store) func(migrator *Migrator) {
+	return func(m *Migrator) {
+		m.store = store
+	}
+}
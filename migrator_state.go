@@ -0,0 +1,51 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// setMigratorState stores the value under the key in Options.StateSpace, nil value deletes the key.
+// With broadcast the value is also sent to watchers of the key.
+func setMigratorState(ctx context.Context, tt pool.Pooler, opts Options, key string, value any, broadcast bool) error {
+	data, err := LuaFs.ReadFile(setMigratorStatePath)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{opts.StateSpace, key, value, broadcast})
+
+	_, err = tt.Do(req, opts.WriteMode).Get()
+	if err != nil {
+		return fmt.Errorf("set migrator state: %w", err)
+	}
+
+	return nil
+}
+
+// getMigratorState returns the value stored under the key in Options.StateSpace or nil.
+func getMigratorState[T any](ctx context.Context, tt pool.Pooler, opts Options, mode pool.Mode,
+	key string) (*T, error) {
+	data, err := LuaFs.ReadFile(getMigratorStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
+
+	var values []*T
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{opts.StateSpace, key})
+
+	err = tt.Do(req, mode).GetTyped(&values)
+	if err != nil {
+		return nil, fmt.Errorf("get migrator state: %w", err)
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	return values[0], nil
+}
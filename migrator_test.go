@@ -157,28 +157,59 @@ func (suite *MigratorTestSuite) TestMigrateMigrationMigrateError() {
 }
 
 func (suite *MigratorTestSuite) TestMigrateSuccess() {
+	tt := newGoldenPooler(suite.T(), "migrator_migrate_success",
+		[][]interface{}{}, [][]interface{}{}, [][]interface{}{}, [][]interface{}{},
+		[][]interface{}{}, [][]interface{}{}, [][]interface{}{})
+
+	testable := NewMigrator(tt, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(suite.testable.opts))
+	err := testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	tt.check(suite.T())
+}
+
+func (suite *MigratorTestSuite) TestMigrateMigrationInDriveRunMode() {
+	tt := newGoldenPooler(suite.T(), "migrator_migrate_dry_run", [][]interface{}{}, [][]interface{}{})
+
+	suite.testable.opts.DryRun = true
+	testable := NewMigrator(tt, MigrationsCollection{
+		{ID: "migrate-in-drive-run-mode", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(suite.testable.opts))
+	err := testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	tt.check(suite.T())
+}
+
+func (suite *MigratorTestSuite) TestMigrateMigrationNotConfirmed() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	mockDoer.AddResponseRaw([][]interface{}{})
-	mockDoer.AddResponseRaw([][]interface{}{})
-	mockDoer.AddResponseRaw(newMigrationTupleStubResponseBody())
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
 
 	migrations := make(MigrationsCollection, 0, 1)
 	migrations = append(migrations, &Migration{
-		ID:      "migration-success",
-		Migrate: NewGenericMigrateFunction("box.info"),
+		ID:                   "migration-not-confirmed",
+		Migrate:              NewGenericMigrateFunction("box.info"),
+		RequiresConfirmation: true,
 	})
 	suite.testable.migrations = migrations
+	WithConfirmFunc(func(ctx context.Context, migration *Migration) (bool, error) {
+		return false, nil
+	})(suite.testable)
 	err := suite.testable.Migrate(suite.ctx)
 	calls := suite.mock.DoCalls()
-	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), calls, 4)
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrMigrationNotConfirmed)
+	assert.Equal(suite.T(), `migration "migration-not-confirmed" error: migration requires manual confirmation`,
+		err.Error())
+	assert.Len(suite.T(), calls, 2)
 }
 
-func (suite *MigratorTestSuite) TestMigrateMigrationInDriveRunMode() {
+func (suite *MigratorTestSuite) TestMigrateMigrationWithoutConfirmFunc() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	mockDoer.AddResponseRaw([][]interface{}{})
@@ -188,15 +219,43 @@ func (suite *MigratorTestSuite) TestMigrateMigrationInDriveRunMode() {
 
 	migrations := make(MigrationsCollection, 0, 1)
 	migrations = append(migrations, &Migration{
-		ID:      "migrate-in-drive-run-mode",
-		Migrate: NewGenericMigrateFunction("box.info"),
+		ID:                   "migration-without-confirm-func",
+		Migrate:              NewGenericMigrateFunction("box.info"),
+		RequiresConfirmation: true,
 	})
 	suite.testable.migrations = migrations
-	suite.testable.opts.DryRun = true
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrMigrationNotConfirmed)
+}
+
+func (suite *MigratorTestSuite) TestMigrateMigrationConfirmed() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw(newMigrationTupleStubResponseBody())
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	var confirmed []string
+	migrations := make(MigrationsCollection, 0, 1)
+	migrations = append(migrations, &Migration{
+		ID:                   "migration-confirmed",
+		Migrate:              NewGenericMigrateFunction("box.info"),
+		RequiresConfirmation: true,
+	})
+	suite.testable.migrations = migrations
+	WithConfirmFunc(func(ctx context.Context, migration *Migration) (bool, error) {
+		confirmed = append(confirmed, migration.ID)
+		return true, nil
+	})(suite.testable)
 	err := suite.testable.Migrate(suite.ctx)
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), calls, 2)
+	assert.Equal(suite.T(), []string{"migration-confirmed"}, confirmed)
+	assert.Len(suite.T(), calls, 4)
 }
 
 func (suite *MigratorTestSuite) TestRollbackLastWithoutMigrations() {
@@ -304,50 +363,188 @@ func (suite *MigratorTestSuite) TestRollbackMigrationRollbackError() {
 }
 
 func (suite *MigratorTestSuite) TestRollbackMigrationSuccess() {
-	body := newMigrationTupleStubResponseBody()
-	migrationId := fmt.Sprintf("%v", body[0][0])
+	tt := newGoldenPooler(suite.T(), "migrator_rollback_last_success",
+		newAppliedMigrationResponseBody("migration-2"), [][]interface{}{}, [][]interface{}{})
+
+	testable := NewMigrator(tt, MigrationsCollection{
+		{ID: "migration-1", Rollback: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Rollback: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(suite.testable.opts))
+	err := testable.RollbackLast(suite.ctx)
+	assert.NoError(suite.T(), err)
+	tt.check(suite.T())
+}
 
+func (suite *MigratorTestSuite) TestRollbackMigrationInDriveRunMode() {
+	tt := newGoldenPooler(suite.T(), "migrator_rollback_last_dry_run", newAppliedMigrationResponseBody("migration-1"))
+
+	suite.testable.opts.DryRun = true
+	testable := NewMigrator(tt, MigrationsCollection{
+		{ID: "migration-1", Rollback: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(suite.testable.opts))
+	err := testable.RollbackLast(suite.ctx)
+	assert.NoError(suite.T(), err)
+	tt.check(suite.T())
+}
+
+func (suite *MigratorTestSuite) TestMigrateWithStore() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
-	mockDoer.AddResponseRaw(body)
 	mockDoer.AddResponseRaw([][]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	store := NewMemoryStore(AppliedMigration{ID: "migration-1"})
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{Lock: true}), WithStore(store))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+
+	applied, _ := store.List(suite.ctx)
+	assert.Len(suite.T(), applied, 2)
+	assert.NoError(suite.T(), store.Lock(suite.ctx))
+}
+
+func (suite *MigratorTestSuite) TestMigrateStoreLocked() {
+	store := NewMemoryStore()
+	assert.NoError(suite.T(), store.Lock(suite.ctx))
+
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{Lock: true}), WithStore(store))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrStoreLocked)
+	assert.Equal(suite.T(), "lock migrations store error: migrations store is locked", err.Error())
+
+	err = suite.testable.RollbackLast(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrStoreLocked)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *MigratorTestSuite) TestMigrateWithResult() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
 
-	migrations := make(MigrationsCollection, 0, 1)
-	migrations = append(migrations, &Migration{
-		ID:       migrationId,
-		Rollback: NewGenericMigrateFunction("box.info"),
-	})
-	suite.testable.migrations = migrations
-	err := suite.testable.RollbackLast(suite.ctx)
-	calls := suite.mock.DoCalls()
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-3", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-4", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{}), WithStore(NewMemoryStore(AppliedMigration{ID: "migration-1"})))
+
+	result, err := suite.testable.MigrateWithResult(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-3" error: user migrate: eval lua: tarantool error`, err.Error())
+	assert.Equal(suite.T(), err, result.Err)
+	assert.Equal(suite.T(), MigrationDirectionUp, result.Direction)
+	assert.Equal(suite.T(), ResultTotals{Total: 3, Applied: 1, AlreadyApplied: 1, Failed: 1}, result.Totals)
+	assert.Equal(suite.T(), []string{"migration-1", "migration-2", "migration-3"},
+		[]string{result.Migrations[0].ID, result.Migrations[1].ID, result.Migrations[2].ID})
+	assert.Equal(suite.T(), MigrationActionAlreadyApplied, result.Migrations[0].Action)
+	assert.Equal(suite.T(), MigrationActionApplied, result.Migrations[1].Action)
+	assert.Equal(suite.T(), MigrationActionFailed, result.Migrations[2].Action)
+	assert.Equal(suite.T(), "user migrate: eval lua: tarantool error", result.Migrations[2].Err.Error())
+}
+
+func (suite *MigratorTestSuite) TestMigrateWithResultDryRun() {
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{DryRun: true}), WithStore(NewMemoryStore()))
+
+	result, err := suite.testable.MigrateWithResult(suite.ctx)
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), calls, 3)
+	assert.Equal(suite.T(), ResultTotals{Total: 1, DryRun: 1}, result.Totals)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
 }
 
-func (suite *MigratorTestSuite) TestRollbackMigrationInDriveRunMode() {
+func (suite *MigratorTestSuite) TestMigrateWithResultWithoutMigrations() {
+	result, err := suite.testable.MigrateWithResult(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrNoDefinedMigrations)
+	assert.Equal(suite.T(), ErrNoDefinedMigrations, result.Err)
+	assert.Empty(suite.T(), result.Migrations)
+}
+
+func (suite *MigratorTestSuite) TestRollbackLastWithResult() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	store := NewMemoryStore(AppliedMigration{ID: "migration-1"})
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Rollback: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{}), WithStore(store))
+
+	result, err := suite.testable.RollbackLastWithResult(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), MigrationDirectionDown, result.Direction)
+	assert.Equal(suite.T(), ResultTotals{Total: 1, RolledBack: 1}, result.Totals)
+	assert.Equal(suite.T(), MigrationActionRolledBack, result.Migrations[0].Action)
+
+	applied, _ := store.List(suite.ctx)
+	assert.Empty(suite.T(), applied)
+}
+
+func (suite *MigratorTestSuite) TestStatus() {
 	body := newMigrationTupleStubResponseBody()
-	migrationId := fmt.Sprintf("%v", body[0][0])
+	body[0][0] = "migration-1"
 
 	mockDoer := test_helpers.NewMockDoer(suite.T())
-	mockDoer.AddResponseRaw(body)
+	mockDoer.AddResponseRaw([][][]interface{}{body})
+	mockDoer.AddResponseRaw([][]interface{}{{map[string]any{
+		"id": "migration-2", "direction": "up", "last": []any{10}, "processed": 10,
+	}}})
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
 
-	migrations := make(MigrationsCollection, 0, 1)
-	migrations = append(migrations, &Migration{
-		ID:       migrationId,
-		Rollback: NewGenericMigrateFunction("box.info"),
-	})
-	suite.testable.migrations = migrations
-	suite.testable.opts.DryRun = true
-	err := suite.testable.RollbackLast(suite.ctx)
-	calls := suite.mock.DoCalls()
+	suite.testable.migrations = MigrationsCollection{{ID: "migration-1"}, {ID: "migration-2"}}
+	status, err := suite.testable.Status(suite.ctx)
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), []string{"migration-1"}, status.Applied)
+	assert.Equal(suite.T(), []string{"migration-2"}, status.Pending)
+	assert.Equal(suite.T(), []MigrationCheckpoint{
+		{ID: "migration-2", Direction: MigrationDirectionUp, Last: []any{int8(10)}, Processed: 10},
+	}, status.InProgress)
+	assert.False(suite.T(), status.IsUpToDate())
+
+	rec, err := describeRequest(suite.mock.DoCalls()[1].Req, pool.ModeAny)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{suite.testable.opts.StateSpace, checkpointKeyPrefix}, rec.Args)
+}
+
+func (suite *MigratorTestSuite) TestStatusError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	status, err := suite.testable.Status(suite.ctx)
+	assert.Nil(suite.T(), status)
+	assert.Equal(suite.T(), "migrations status error: list applied migrations: tarantool error", err.Error())
+}
+
+func (suite *MigratorTestSuite) TestStatusCheckpointsError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][][]interface{}{})
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	status, err := suite.testable.Status(suite.ctx)
+	assert.Nil(suite.T(), status)
+	assert.Equal(suite.T(), "migrations status error: list checkpoints: tarantool error", err.Error())
 }
 
 func TestMigratorTestSuite(t *testing.T) {
@@ -1,8 +1,37 @@
 package tarantool_migrator
 
-import "github.com/tarantool/go-tarantool/v3/pool"
+import (
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
 
 const createMigrationsSpacePath = "lua/migrations/create_migrations_space.up.lua"
+const findLastMigrationPath = "lua/functions/find_last_migration.lua"
+const listAppliedMigrationsPath = "lua/functions/list_applied_migrations.lua"
+const vshardReplicasetsPath = "lua/functions/vshard_replicasets.lua"
+const replicationStatePath = "lua/functions/replication_state.lua"
+const publishSchemaVersionPath = "lua/functions/publish_schema_version.lua"
+const setMigratorStatePath = "lua/functions/set_migrator_state.lua"
+const getMigratorStatePath = "lua/functions/get_migrator_state.lua"
+const preflightPath = "lua/functions/preflight.lua"
+const serverVersionPath = "lua/functions/server_version.lua"
+const listCheckpointsPath = "lua/functions/list_checkpoints.lua"
+const startBackgroundJobPath = "lua/functions/start_background_job.lua"
+const backgroundJobStatusPath = "lua/functions/background_job_status.lua"
+const cancelBackgroundJobPath = "lua/functions/cancel_background_job.lua"
+const rebuildPreparePath = "lua/functions/rebuild_prepare.lua"
+const rebuildSwapPath = "lua/functions/rebuild_swap.lua"
+const rebuildRollbackPath = "lua/functions/rebuild_rollback.lua"
+const undoStartPath = "lua/functions/undo_start.lua"
+const undoStopPath = "lua/functions/undo_stop.lua"
+const undoApplyPath = "lua/functions/undo_apply.lua"
+const undoClearPath = "lua/functions/undo_clear.lua"
+const primaryKeyFieldsPath = "lua/functions/primary_key_fields.lua"
+const lockStorePath = "lua/functions/lock_store.lua"
+const unlockStorePath = "lua/functions/unlock_store.lua"
+const vshardStorageEvalPath = "lua/functions/vshard_storage_eval.lua"
 
 // Options define options for all migrations.
 type Options struct {
@@ -14,12 +43,81 @@ type Options struct {
 	ReadMode pool.Mode `json:"read_mode"`
 	// Default mode for write requests
 	WriteMode pool.Mode `json:"write_mode"`
+	// Wrap lua migrations into box.begin/box.commit
+	Transactional bool `json:"transactional"`
 	// Store custom data for migrations
 	MigrationsContainer map[string]any
+	// Values passed to lua migrations in args.values
+	LuaArgs map[string]any `json:"lua_args"`
+	// Wait for replicas to catch up with the master after migrations
+	WaitReplication ReplicationWait `json:"wait_replication"`
+	// Max duration of waiting for replicas, zero means waiting until the context is done
+	ReplicationTimeout time.Duration `json:"replication_timeout"`
+	// Interval between replication checks, 100ms by default
+	ReplicationPollInterval time.Duration `json:"replication_poll_interval"`
+	// Interval between status checks of WaitUntilApplied, 1s by default
+	StatusPollInterval time.Duration `json:"status_poll_interval"`
+	// Key of SchemaVersion in StateSpace and its box.broadcast key, empty (default) disables it
+	SchemaVersionKey string `json:"schema_version_key"`
+	// Space of migrator state, e.g. maintenance mode
+	StateSpace string `json:"state_space"`
+	// State key and box.broadcast key of maintenance mode
+	MaintenanceKey string `json:"maintenance_key"`
+	// Check instance state and privileges before running migrations, disabled by default
+	Preflight bool `json:"preflight"`
+	// What to do with migrations not supporting the server version
+	VersionPolicy VersionPolicy `json:"version_policy"`
+	// Space of background jobs started by NewBackgroundMigrateFunction
+	JobsSpace string `json:"jobs_space"`
+	// Interval between status checks of background jobs, 1s by default
+	JobPollInterval time.Duration `json:"job_poll_interval"`
+	// Timeout of every request to background jobs, 10s by default
+	JobRequestTimeout time.Duration `json:"job_request_timeout"`
+	// Space of undo journals recorded for Migration.UndoSpaces
+	UndoSpace string `json:"undo_space"`
+	// Local directory of backups taken before migrations with Migration.BackupSpaces
+	BackupDir string `json:"backup_dir"`
+	// Format of backup files, msgpack by default
+	BackupFormat BackupFormat `json:"backup_format"`
+	// Allow file migrations with destructive calls, e.g. space:drop() or space:truncate()
+	AllowDestructive bool `json:"allow_destructive"`
+	// Lock the store while Migrate or RollbackLast is running, a concurrent run fails with ErrStoreLocked
+	Lock bool `json:"lock"`
 }
 
 var DefaultOptions = Options{
 	MigrationsSpace: "migrations",
 	ReadMode:        pool.ModeAny,
 	WriteMode:       pool.ModeRW,
+	StateSpace:      DefaultStateSpace,
+	MaintenanceKey:  DefaultMaintenanceKey,
+	JobsSpace:       DefaultJobsSpace,
+	UndoSpace:       DefaultUndoSpace,
+	BackupDir:       DefaultBackupDir,
+}
+
+var poolModeNames = map[pool.Mode]string{
+	pool.ModeAny:      "any",
+	pool.ModeRW:       "rw",
+	pool.ModeRO:       "ro",
+	pool.ModePreferRW: "prefer_rw",
+	pool.ModePreferRO: "prefer_ro",
+}
+
+func poolModeName(mode pool.Mode) string {
+	if name, ok := poolModeNames[mode]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("mode(%d)", mode)
+}
+
+func parsePoolMode(name string) (pool.Mode, error) {
+	for mode, modeName := range poolModeNames {
+		if modeName == name {
+			return mode, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown pool mode %q", name)
 }
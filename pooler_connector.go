@@ -0,0 +1,98 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// connectorPooler serves every mode with the single connection: for the migrator a standalone instance
+// is both the master and the replica, so ReadMode and WriteMode need no changes.
+type connectorPooler struct {
+	doer tarantool.Doer
+}
+
+// NewConnectorPooler adapts *tarantool.Connection, tarantool.Connector or a plain tarantool.Doer to pool.Pooler,
+// so a single-instance service can be migrated without a pool. Methods missing in the doer
+// return ErrConnectorUnsupported, topology can't be changed.
+func NewConnectorPooler(doer tarantool.Doer) pool.Pooler {
+	return &connectorPooler{doer: doer}
+}
+
+// NewConnectionMigrator creates migrator on the single connection, see NewConnectorPooler.
+func NewConnectionMigrator(doer tarantool.Doer, migrations MigrationsCollection,
+	options ...func(*Migrator)) *Migrator {
+	return NewMigrator(NewConnectorPooler(doer), migrations, options...)
+}
+
+func (cp *connectorPooler) Do(req tarantool.Request, _ pool.Mode) tarantool.Future {
+	return cp.doer.Do(req)
+}
+
+func (cp *connectorPooler) ConnectedNow(_ pool.Mode) (bool, error) {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.ConnectedNow(), nil
+	}
+
+	return true, nil
+}
+
+func (cp *connectorPooler) Add(_ context.Context, instance pool.Instance) error {
+	return fmt.Errorf("%w: add instance %s", ErrConnectorUnsupported, instance.Name)
+}
+
+func (cp *connectorPooler) Remove(name string) error {
+	return fmt.Errorf("%w: remove instance %s", ErrConnectorUnsupported, name)
+}
+
+func (cp *connectorPooler) Close() error {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.Close()
+	}
+
+	return nil
+}
+
+func (cp *connectorPooler) CloseGraceful() error {
+	if conn, ok := cp.doer.(interface{ CloseGraceful() error }); ok {
+		return conn.CloseGraceful()
+	}
+
+	return cp.Close()
+}
+
+func (cp *connectorPooler) ConfiguredTimeout(_ pool.Mode) (time.Duration, error) {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.ConfiguredTimeout(), nil
+	}
+
+	return 0, fmt.Errorf("%w: configured timeout", ErrConnectorUnsupported)
+}
+
+func (cp *connectorPooler) NewPrepared(expr string, _ pool.Mode) (*tarantool.Prepared, error) {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.NewPrepared(expr)
+	}
+
+	return nil, fmt.Errorf("%w: prepared statements", ErrConnectorUnsupported)
+}
+
+func (cp *connectorPooler) NewStream(_ pool.Mode) (*tarantool.Stream, error) {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.NewStream()
+	}
+
+	return nil, fmt.Errorf("%w: streams", ErrConnectorUnsupported)
+}
+
+func (cp *connectorPooler) NewWatcher(key string, callback tarantool.WatchCallback,
+	_ pool.Mode) (tarantool.Watcher, error) {
+	if conn, ok := cp.doer.(tarantool.Connector); ok {
+		return conn.NewWatcher(key, callback)
+	}
+
+	return nil, fmt.Errorf("%w: watchers", ErrConnectorUnsupported)
+}
@@ -0,0 +1,120 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type connectorStub struct {
+	test_helpers.MockDoer
+	connected bool
+	closed    bool
+}
+
+func (c *connectorStub) ConnectedNow() bool {
+	return c.connected
+}
+
+func (c *connectorStub) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func (c *connectorStub) ConfiguredTimeout() time.Duration {
+	return time.Second
+}
+
+func (c *connectorStub) NewPrepared(_ string) (*tarantool.Prepared, error) {
+	return &tarantool.Prepared{}, nil
+}
+
+func (c *connectorStub) NewStream() (*tarantool.Stream, error) {
+	return &tarantool.Stream{}, nil
+}
+
+func (c *connectorStub) NewWatcher(_ string, _ tarantool.WatchCallback) (tarantool.Watcher, error) {
+	return nil, nil
+}
+
+func TestConnectorPoolerDo(t *testing.T) {
+	doer := test_helpers.NewMockDoer(t)
+	doer.AddResponseRaw([]interface{}{})
+	doer.AddResponseRaw([]interface{}{})
+
+	testable := NewConnectorPooler(doer)
+	for _, mode := range []pool.Mode{pool.ModeRW, pool.ModeRO} {
+		_, err := testable.Do(tarantool.NewEvalRequest("box.info"), mode).Get()
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, doer.Requests(), 2)
+}
+
+func TestConnectorPoolerWithDoer(t *testing.T) {
+	doer := test_helpers.NewMockDoer(t)
+	testable := NewConnectorPooler(doer)
+
+	connected, err := testable.ConnectedNow(pool.ModeRW)
+	assert.NoError(t, err)
+	assert.True(t, connected)
+	assert.NoError(t, testable.Close())
+	assert.NoError(t, testable.CloseGraceful())
+
+	_, err = testable.ConfiguredTimeout(pool.ModeRW)
+	assert.ErrorIs(t, err, ErrConnectorUnsupported)
+
+	_, err = testable.NewStream(pool.ModeRW)
+	assert.Equal(t, "not supported by single connection: streams", err.Error())
+
+	_, err = testable.NewWatcher("key", nil, pool.ModeRW)
+	assert.ErrorIs(t, err, ErrConnectorUnsupported)
+
+	err = testable.Add(context.Background(), pool.Instance{Name: "replica"})
+	assert.Equal(t, "not supported by single connection: add instance replica", err.Error())
+	assert.ErrorIs(t, testable.Remove("replica"), ErrConnectorUnsupported)
+}
+
+func TestConnectorPoolerWithConnector(t *testing.T) {
+	conn := &connectorStub{MockDoer: test_helpers.NewMockDoer(t), connected: true}
+	testable := NewConnectorPooler(conn)
+
+	connected, err := testable.ConnectedNow(pool.ModeRO)
+	assert.NoError(t, err)
+	assert.True(t, connected)
+
+	timeout, err := testable.ConfiguredTimeout(pool.ModeAny)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, timeout)
+
+	_, err = testable.NewPrepared("select 1", pool.ModeRW)
+	assert.NoError(t, err)
+
+	_, err = testable.NewStream(pool.ModeRW)
+	assert.NoError(t, err)
+
+	assert.NoError(t, testable.CloseGraceful())
+	assert.True(t, conn.closed)
+}
+
+func TestNewConnectionMigrator(t *testing.T) {
+	doer := test_helpers.NewMockDoer(t)
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+
+	opts := DefaultOptions
+	testable := NewConnectionMigrator(doer, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&opts))
+
+	assert.NoError(t, testable.Migrate(context.Background()))
+	assert.Len(t, doer.Requests(), 4)
+}
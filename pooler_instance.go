@@ -0,0 +1,34 @@
+package tarantool_migrator
+
+import (
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// InstancesPooler is a pool.Pooler which can list its instances and send requests to a chosen one.
+// *pool.Pool implements it.
+type InstancesPooler interface {
+	pool.Pooler
+	Info() map[string]pool.Info
+	DoOn(req tarantool.Request, name string) tarantool.Future
+}
+
+// instancePooler sends all requests to the single pool instance regardless of mode.
+type instancePooler struct {
+	InstancesPooler
+	name string
+}
+
+func (ip *instancePooler) Do(req tarantool.Request, _ pool.Mode) tarantool.Future {
+	return ip.DoOn(req, ip.name)
+}
+
+func (ip *instancePooler) ConnectedNow(_ pool.Mode) (bool, error) {
+	info, ok := ip.Info()[ip.name]
+
+	return ok && info.ConnectedNow, nil
+}
+
+func newInstancePooler(tt InstancesPooler, name string) pool.Pooler {
+	return &instancePooler{InstancesPooler: tt, name: name}
+}
@@ -0,0 +1,31 @@
+package tarantool_migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+func TestInstancePoolerDo(t *testing.T) {
+	stub := newInstancesPoolerStub(t, map[string]pool.Role{"master": pool.RoleMaster, "replica": pool.RoleReplica})
+	stub.doers["replica"].AddResponseRaw([]interface{}{})
+
+	testable := newInstancePooler(stub, "replica")
+	_, err := testable.Do(tarantool.NewEvalRequest("box.info"), pool.ModeRW).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"replica"}, stub.calls)
+}
+
+func TestInstancePoolerConnectedNow(t *testing.T) {
+	stub := newInstancesPoolerStub(t, map[string]pool.Role{"master": pool.RoleMaster})
+
+	connected, err := newInstancePooler(stub, "master").ConnectedNow(pool.ModeRW)
+	assert.NoError(t, err)
+	assert.True(t, connected)
+
+	connected, err = newInstancePooler(stub, "unknown").ConnectedNow(pool.ModeRW)
+	assert.NoError(t, err)
+	assert.False(t, connected)
+}
@@ -0,0 +1,176 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const vshardSelectExpr = `local space, index, key, opts = ...
+return unpack(box.space[space].index[index]:select(key, opts))`
+
+const vshardInsertExpr = `local space, tuple = ...
+return box.space[space]:insert(tuple)`
+
+const vshardReplaceExpr = `local space, tuple = ...
+return box.space[space]:replace(tuple)`
+
+const vshardDeleteExpr = `local space, index, key = ...
+return box.space[space].index[index]:delete(key)`
+
+// vshardOpsIndexBase converts zero-based field numbers of iproto update operations into lua ones.
+const vshardOpsIndexBase = `for _, op in ipairs(ops) do
+    if type(op[2]) == 'number' and op[2] >= 0 then
+        op[2] = op[2] + 1
+    end
+end
+`
+
+const vshardUpdateExpr = `local space, index, key, ops = ...
+` + vshardOpsIndexBase + `return box.space[space].index[index]:update(key, ops)`
+
+const vshardUpsertExpr = `local space, tuple, ops = ...
+` + vshardOpsIndexBase + `return box.space[space]:upsert(tuple, ops)`
+
+const vshardCallExpr = `local name, args = ...
+local fn = _G
+for part in string.gmatch(name, '[^.]+') do
+    fn = type(fn) == 'table' and fn[part] or nil
+end
+if fn == nil then
+    error(string.format("function '%s' is not defined", name))
+end
+return fn(unpack(args))`
+
+const vshardExecuteExpr = `local sql, args = ...
+local res, err = box.execute(sql, args)
+if err ~= nil then
+    error(err)
+end
+return res`
+
+// vshardStoragePooler sends every request to the master of a vshard replicaset through the router.
+// Requests are converted to lua expressions evaluated on the storage, so the router user needs
+// eval access to storages. The mode is ignored, storages are always accessed through the master.
+type vshardStoragePooler struct {
+	pool.Pooler
+	replicaset string
+}
+
+func (vp *vshardStoragePooler) Do(req tarantool.Request, _ pool.Mode) tarantool.Future {
+	expr, args, err := vshardStorageExpr(req)
+	if err != nil {
+		return newRecordedFuture(nil, err)
+	}
+
+	data, err := LuaFs.ReadFile(vshardStorageEvalPath)
+	if err != nil {
+		return newRecordedFuture(nil, fmt.Errorf("read lua script: %w", err))
+	}
+
+	ctx := req.Ctx()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var timeout any
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline).Seconds()
+	}
+
+	eval := tarantool.NewEvalRequest(string(data)).Context(ctx).
+		Args([]any{vp.replicaset, expr, args, timeout})
+
+	return vp.Pooler.Do(eval, pool.ModeAny)
+}
+
+// vshardStorageExpr converts the request into lua expression with its arguments.
+func vshardStorageExpr(req tarantool.Request) (string, []any, error) {
+	body, err := decodeRequestBody(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	space := body[iproto.IPROTO_SPACE_NAME]
+	if space == nil {
+		space = body[iproto.IPROTO_SPACE_ID]
+	}
+
+	index := body[iproto.IPROTO_INDEX_NAME]
+	if index == nil {
+		index = body[iproto.IPROTO_INDEX_ID]
+	}
+
+	if index == nil {
+		index = 0
+	}
+
+	switch req.Type() {
+	case iproto.IPROTO_EVAL:
+		expr, _ := body[iproto.IPROTO_EXPR].(string)
+		args, _ := body[iproto.IPROTO_TUPLE].([]any)
+
+		return expr, args, nil
+	case iproto.IPROTO_SELECT:
+		opts := map[string]any{
+			"iterator": body[iproto.IPROTO_ITERATOR],
+			"limit":    body[iproto.IPROTO_LIMIT],
+			"offset":   body[iproto.IPROTO_OFFSET],
+		}
+
+		return vshardSelectExpr, []any{space, index, body[iproto.IPROTO_KEY], opts}, nil
+	case iproto.IPROTO_INSERT:
+		return vshardInsertExpr, []any{space, body[iproto.IPROTO_TUPLE]}, nil
+	case iproto.IPROTO_REPLACE:
+		return vshardReplaceExpr, []any{space, body[iproto.IPROTO_TUPLE]}, nil
+	case iproto.IPROTO_DELETE:
+		return vshardDeleteExpr, []any{space, index, body[iproto.IPROTO_KEY]}, nil
+	case iproto.IPROTO_UPDATE:
+		return vshardUpdateExpr, []any{space, index, body[iproto.IPROTO_KEY], body[iproto.IPROTO_TUPLE]}, nil
+	case iproto.IPROTO_UPSERT:
+		return vshardUpsertExpr, []any{space, body[iproto.IPROTO_TUPLE], body[iproto.IPROTO_OPS]}, nil
+	case iproto.IPROTO_CALL:
+		return vshardCallExpr, []any{body[iproto.IPROTO_FUNCTION_NAME], body[iproto.IPROTO_TUPLE]}, nil
+	case iproto.IPROTO_EXECUTE:
+		return vshardExecuteExpr, []any{body[iproto.IPROTO_SQL_TEXT], body[iproto.IPROTO_SQL_BIND]}, nil
+	default:
+		return "", nil, fmt.Errorf("%w: %s", ErrVshardUnsupportedRequest, req.Type().String())
+	}
+}
+
+func newVshardStoragePooler(router pool.Pooler, replicaset string) pool.Pooler {
+	return &vshardStoragePooler{Pooler: router, replicaset: replicaset}
+}
+
+// vshardTargets are replicasets known to the vshard router.
+type vshardTargets struct {
+	router pool.Pooler
+}
+
+func (t *vshardTargets) list(ctx context.Context) ([]string, error) {
+	data, err := LuaFs.ReadFile(vshardReplicasetsPath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
+
+	var ids [][]string
+
+	err = t.router.Do(tarantool.NewEvalRequest(string(data)).Context(ctx), pool.ModeAny).GetTyped(&ids)
+	if err != nil {
+		return nil, fmt.Errorf("list vshard replicasets: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return ids[0], nil
+}
+
+func (t *vshardTargets) pooler(name string) pool.Pooler {
+	return newVshardStoragePooler(t.router, name)
+}
@@ -0,0 +1,176 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type VshardStoragePoolerTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable pool.Pooler
+}
+
+func (suite *VshardStoragePoolerTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.testable = newVshardStoragePooler(suite.mock, "replicaset-1")
+}
+
+func (suite *VshardStoragePoolerTestSuite) lastRequest() RecordedRequest {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[len(calls)-1].Req, calls[len(calls)-1].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoEval() {
+	suite.doer.AddResponseRaw([]interface{}{"foo"})
+
+	data, err := suite.testable.Do(tarantool.NewEvalRequest("return ...").Args([]any{"foo"}), pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"foo"}, data)
+
+	rec := suite.lastRequest()
+	expected, _ := LuaFs.ReadFile(vshardStorageEvalPath)
+	assert.Equal(suite.T(), "any", rec.Mode)
+	assert.Equal(suite.T(), string(expected), rec.Expr)
+	assert.Equal(suite.T(), []any{"replicaset-1", "return ...", []any{"foo"}, nil}, rec.Args)
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoSelect() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	req := tarantool.NewSelectRequest("migrations").Index("id").Key([]any{"qwerty"}).Limit(1)
+	_, err := suite.testable.Do(req, pool.ModeAny).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardSelectExpr, args[1])
+	assert.Equal(suite.T(), []any{
+		"migrations", "id", []any{"qwerty"},
+		map[string]any{"iterator": int8(0), "limit": int8(1), "offset": int8(0)},
+	}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoInsert() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	_, err := suite.testable.Do(tarantool.NewInsertRequest("migrations").Tuple([]any{"qwerty"}), pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardInsertExpr, args[1])
+	assert.Equal(suite.T(), []any{"migrations", []any{"qwerty"}}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoDelete() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	_, err := suite.testable.Do(tarantool.NewDeleteRequest("migrations").Key([]any{"qwerty"}), pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardDeleteExpr, args[1])
+	assert.Equal(suite.T(), []any{"migrations", int8(0), []any{"qwerty"}}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoUpdate() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	req := tarantool.NewUpdateRequest("users").Index("email").Key([]any{"a@b.c"}).
+		Operations(tarantool.NewOperations().Assign(1, "Bob"))
+	_, err := suite.testable.Do(req, pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardUpdateExpr, args[1])
+	assert.Equal(suite.T(), []any{"users", "email", []any{"a@b.c"}, []any{[]any{"=", int8(1), "Bob"}}}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoUpsert() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	req := tarantool.NewUpsertRequest("counters").Tuple([]any{"hits", 1}).
+		Operations(tarantool.NewOperations().Add(1, 1))
+	_, err := suite.testable.Do(req, pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardUpsertExpr, args[1])
+	assert.Equal(suite.T(), []any{"counters", []any{"hits", int8(1)}, []any{[]any{"+", int8(1), int8(1)}}}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoCall() {
+	suite.doer.AddResponseRaw([]interface{}{"ok"})
+
+	_, err := suite.testable.Do(tarantool.NewCallRequest("app.reload").Args([]any{"users"}), pool.ModeRW).Get()
+	assert.NoError(suite.T(), err)
+
+	args := suite.lastRequest().Args.([]any)
+	assert.Equal(suite.T(), vshardCallExpr, args[1])
+	assert.Equal(suite.T(), []any{"app.reload", []any{"users"}}, args[2])
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestDoUnsupported() {
+	_, err := suite.testable.Do(tarantool.NewPingRequest(), pool.ModeRW).Get()
+
+	assert.ErrorIs(suite.T(), err, ErrVshardUnsupportedRequest)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestTargets() {
+	suite.doer.AddResponseRaw([][]string{{"replicaset-1", "replicaset-2"}})
+
+	testable := NewVshardRollout(suite.mock, nil)
+	targets, err := testable.Targets(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"replicaset-1", "replicaset-2"}, targets)
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestTargetsEmpty() {
+	suite.doer.AddResponseRaw([][]string{{}})
+
+	testable := NewVshardRollout(suite.mock, nil)
+	targets, err := testable.Targets(suite.ctx)
+	assert.Nil(suite.T(), targets)
+	assert.ErrorIs(suite.T(), err, ErrNoWritableInstances)
+}
+
+func (suite *VshardStoragePoolerTestSuite) TestMigrate() {
+	suite.mock.ConnectedNowFunc = func(mode pool.Mode) (bool, error) {
+		return true, nil
+	}
+	suite.doer.AddResponseRaw([][]string{{"replicaset-1"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	testable := NewVshardRollout(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithRolloutLogger(SilentLogger))
+	results, err := testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 1)
+	assert.Equal(suite.T(), "replicaset-1", results[0].Instance)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 5)
+}
+
+func TestVshardStoragePoolerTestSuite(t *testing.T) {
+	suite.Run(t, new(VshardStoragePoolerTestSuite))
+}
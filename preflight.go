@@ -0,0 +1,73 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// PreflightCheck is the func signature of user checks run before any migration.
+type PreflightCheck func(ctx context.Context, tt pool.Pooler, opts Options) error
+
+// preflightState is the result of the preflight lua script.
+type preflightState struct {
+	_msgpack struct{} `msgpack:",as_array"` //nolint:unused
+	// Problems are failed instance checks
+	Problems []string
+	// Version is box.info.version
+	Version string
+}
+
+// preflight checks the instance with Options.Preflight and runs user checks before Migrate or RollbackLast
+// touches anything. All failed checks are returned together. Version bounds of migrations are checked later
+// for pending migrations only, the fetched server version is kept in the run.
+func (m *Migrator) preflight(ctx context.Context, run *migratorRun) error {
+	var errs []error
+
+	if m.opts.Preflight {
+		connected, err := m.tt.ConnectedNow(m.opts.WriteMode)
+		if err != nil || !connected {
+			return fmt.Errorf("%w: %w", ErrPreflightFailed, errors.Join(ErrNotConnected, err))
+		}
+
+		errs = m.checkInstance(ctx, run)
+	}
+
+	for _, check := range m.checks {
+		errs = append(errs, check(ctx, m.tt, *m.opts))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %w", ErrPreflightFailed, err)
+	}
+
+	return nil
+}
+
+// checkInstance returns problems of the instance found by the preflight lua script.
+func (m *Migrator) checkInstance(ctx context.Context, run *migratorRun) []error {
+	state, err := m.ex.preflightState(ctx)
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := make([]error, 0, len(state.Problems))
+	for _, problem := range state.Problems {
+		errs = append(errs, errors.New(problem))
+	}
+
+	if version, err := parseTarantoolVersion(state.Version); err == nil {
+		run.setServerVersion(m.opts.WriteMode, version)
+	}
+
+	return errs
+}
+
+// WithPreflightChecks adds user checks to the preflight phase.
+func WithPreflightChecks(checks ...PreflightCheck) func(*Migrator) {
+	return func(m *Migrator) {
+		m.checks = append(m.checks, checks...)
+	}
+}
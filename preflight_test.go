@@ -0,0 +1,154 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type PreflightTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	mock      *mocks.PoolerMock
+	doer      test_helpers.MockDoer
+	connected bool
+	testable  *Migrator
+}
+
+func (suite *PreflightTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.connected = true
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.mock.ConnectedNowFunc = func(mode pool.Mode) (bool, error) {
+		return suite.connected, nil
+	}
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "2.10"},
+	}, WithLogger(SilentLogger))
+	suite.testable.opts.Preflight = true
+}
+
+func (suite *PreflightTestSuite) TestPreflightSuccess() {
+	suite.doer.AddResponseRaw([]interface{}{[]string{}, "2.11.1-0-g96877bd"})
+
+	run := &migratorRun{}
+	err := suite.testable.preflight(suite.ctx, run)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[pool.Mode]tarantoolVersion{pool.ModeRW: {2, 11, 1}}, run.serverVersions)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+
+	rec, err := describeRequest(calls[0].Req, calls[0].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"migrations", false}, rec.Args)
+}
+
+func (suite *PreflightTestSuite) TestPreflightDryRun() {
+	suite.doer.AddResponseRaw([]interface{}{[]string{}, "2.11.1-0-g96877bd"})
+	suite.testable.opts.DryRun = true
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[0].Req, calls[0].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"migrations", true}, rec.Args)
+}
+
+func (suite *PreflightTestSuite) TestPreflightNotConnected() {
+	suite.connected = false
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.ErrorIs(suite.T(), err, ErrPreflightFailed)
+	assert.ErrorIs(suite.T(), err, ErrNotConnected)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *PreflightTestSuite) TestPreflightJoinsErrors() {
+	suite.doer.AddResponseRaw([]interface{}{
+		[]string{"instance is read-only", `user "guest" can not create spaces`}, "2.8.4-0-g1",
+	})
+	suite.testable.checks = []PreflightCheck{
+		func(ctx context.Context, tt pool.Pooler, opts Options) error {
+			return nil
+		},
+		func(ctx context.Context, tt pool.Pooler, opts Options) error {
+			return fmt.Errorf("disk is full")
+		},
+	}
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.ErrorIs(suite.T(), err, ErrPreflightFailed)
+	assert.Equal(suite.T(), "preflight failed: instance is read-only\n"+
+		`user "guest" can not create spaces`+"\n"+
+		"disk is full", err.Error())
+}
+
+func (suite *PreflightTestSuite) TestPreflightStateError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.Equal(suite.T(), "preflight failed: check instance: tarantool error", err.Error())
+}
+
+func (suite *PreflightTestSuite) TestPreflightDisabled() {
+	suite.testable.opts.Preflight = false
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.mock.ConnectedNowCalls())
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *PreflightTestSuite) TestPreflightDisabledRunsUserChecks() {
+	suite.testable.opts.Preflight = false
+	suite.testable.checks = []PreflightCheck{
+		func(ctx context.Context, tt pool.Pooler, opts Options) error {
+			return fmt.Errorf("disk is full")
+		},
+	}
+
+	err := suite.testable.preflight(suite.ctx, &migratorRun{})
+	assert.Equal(suite.T(), "preflight failed: disk is full", err.Error())
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *PreflightTestSuite) TestDefaultOptionsDisablePreflight() {
+	assert.False(suite.T(), DefaultOptions.Preflight)
+}
+
+func (suite *PreflightTestSuite) TestMigrateStopsOnPreflight() {
+	suite.doer.AddResponseRaw([]interface{}{[]string{"instance is read-only"}, "2.11.1"})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.True(suite.T(), errors.Is(err, ErrPreflightFailed))
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+}
+
+func (suite *PreflightTestSuite) TestWithPreflightChecks() {
+	check := func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		return nil
+	}
+	fn := WithPreflightChecks(check, check)
+	fn(suite.testable)
+	assert.Len(suite.T(), suite.testable.checks, 2)
+}
+
+func TestPreflightTestSuite(t *testing.T) {
+	suite.Run(t, new(PreflightTestSuite))
+}
@@ -0,0 +1,233 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const defaultRebuildShadowSuffix = "_rebuild"
+const defaultRebuildOldSuffix = "_old"
+const rebuildLogSuffix = "_rebuild_log"
+const rebuildSwapAttempts = 10
+
+// RebuildIndex is the index of the rebuilt space, Options are passed to space:create_index as is.
+type RebuildIndex struct {
+	Name    string         `msgpack:"name"`
+	Options map[string]any `msgpack:"options"`
+}
+
+// RebuildOptions define the new format of the space rebuilt by NewRebuildMigrateFunction.
+type RebuildOptions struct {
+	// Space to rebuild
+	Space string
+	// Format of the new space, passed to box.schema.space.create as is
+	Format []map[string]any
+	// Indexes of the new space, the first one is primary
+	Indexes []RebuildIndex
+	// BatchSize is the number of tuples copied at once, 1000 by default
+	BatchSize uint32
+	// KeyFields are tuple fields of the old primary key (see BatchOptions.KeyFields)
+	KeyFields []int
+	// Throttle is the pause between batches
+	Throttle time.Duration
+	// CaptureWrites records writes to the old space during the copy with on_replace trigger
+	// and replays them on the new space before the swap
+	CaptureWrites bool
+	// ShadowKeyFields are fields of the new primary key in transformed tuples, required by CaptureWrites
+	ShadowKeyFields []int
+	// ShadowSuffix is appended to the name of the new space during the copy, "_rebuild" by default
+	ShadowSuffix string
+	// OldSuffix is appended to the name of the old space kept for rollback, "_old" by default
+	OldSuffix string
+}
+
+func (r RebuildOptions) shadowName() string {
+	if r.ShadowSuffix == "" {
+		return r.Space + defaultRebuildShadowSuffix
+	}
+
+	return r.Space + r.ShadowSuffix
+}
+
+func (r RebuildOptions) oldName() string {
+	if r.OldSuffix == "" {
+		return r.Space + defaultRebuildOldSuffix
+	}
+
+	return r.Space + r.OldSuffix
+}
+
+func (r RebuildOptions) logName() string {
+	return r.Space + rebuildLogSuffix
+}
+
+// TransformFunc converts the tuple of the old space into the tuple of the new format.
+type TransformFunc func(tuple []any) ([]any, error)
+
+// NewRebuildMigrateFunction creates migrate function which rebuilds the space in the new format:
+// it creates the shadow space, copies tuples in batches through fn, replays captured writes
+// and renames spaces atomically. The old space is kept under RebuildOptions.OldSuffix for rollback.
+func NewRebuildMigrateFunction(rebuildOpts RebuildOptions, fn TransformFunc) MigrateFunc {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		if rebuildOpts.CaptureWrites && len(rebuildOpts.ShadowKeyFields) == 0 {
+			return fmt.Errorf("%w: ShadowKeyFields are required to capture writes", ErrWrongRebuildOptions)
+		}
+
+		err := evalLuaScript(ctx, tt, opts, rebuildPreparePath, rebuildOpts.Space, rebuildOpts.shadowName(),
+			rebuildOpts.oldName(), rebuildOpts.logName(), rebuildOpts.Format, rebuildOpts.Indexes, rebuildOpts.CaptureWrites)
+		if err != nil {
+			return fmt.Errorf("prepare rebuild: %w", err)
+		}
+
+		batchOpts := BatchOptions{
+			Space:     rebuildOpts.Space,
+			BatchSize: rebuildOpts.BatchSize,
+			KeyFields: rebuildOpts.KeyFields,
+			Throttle:  rebuildOpts.Throttle,
+		}
+
+		copyBatch := func(_ context.Context, tuples [][]any) ([]tarantool.Request, error) {
+			return rebuildCopyRequests(rebuildOpts, fn, tuples)
+		}
+
+		if _, err = RunBatches(ctx, tt, opts, batchOpts, copyBatch); err != nil {
+			return fmt.Errorf("copy: %w", err)
+		}
+
+		return swapRebuild(ctx, tt, opts, rebuildOpts, fn)
+	}
+}
+
+// NewRebuildRollbackFunction creates rollback function which drops the new space
+// and restores the old one kept by NewRebuildMigrateFunction.
+func NewRebuildRollbackFunction(rebuildOpts RebuildOptions) RollbackFunc {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		err := evalLuaScript(ctx, tt, opts, rebuildRollbackPath, rebuildOpts.Space, rebuildOpts.shadowName(),
+			rebuildOpts.oldName())
+		if err != nil {
+			return fmt.Errorf("rollback rebuild: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func rebuildCopyRequests(rebuildOpts RebuildOptions, fn TransformFunc, tuples [][]any) ([]tarantool.Request, error) {
+	requests := make([]tarantool.Request, 0, len(tuples))
+
+	for _, tuple := range tuples {
+		transformed, err := fn(tuple)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, tarantool.NewReplaceRequest(rebuildOpts.shadowName()).Tuple(transformed))
+	}
+
+	return requests, nil
+}
+
+// swapRebuild replays captured writes and renames spaces, the swap is retried while new writes arrive.
+func swapRebuild(ctx context.Context, tt pool.Pooler, opts Options, rebuildOpts RebuildOptions,
+	fn TransformFunc) error {
+	for range rebuildSwapAttempts {
+		if rebuildOpts.CaptureWrites {
+			if err := replayRebuildLog(ctx, tt, opts, rebuildOpts, fn); err != nil {
+				return fmt.Errorf("replay captured writes: %w", err)
+			}
+		}
+
+		var swapped []bool
+
+		data, err := LuaFs.ReadFile(rebuildSwapPath)
+		if err != nil {
+			return fmt.Errorf("read lua script: %w", err)
+		}
+
+		req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{rebuildOpts.Space,
+			rebuildOpts.shadowName(), rebuildOpts.oldName(), rebuildOpts.logName()})
+
+		err = tt.Do(req, opts.WriteMode).GetTyped(&swapped)
+		if err != nil {
+			return fmt.Errorf("swap spaces: %w", err)
+		}
+
+		if len(swapped) > 0 && swapped[0] {
+			return nil
+		}
+	}
+
+	return ErrRebuildNotSettled
+}
+
+// replayRebuildLog applies writes captured by on_replace trigger to the shadow space until the log is empty.
+func replayRebuildLog(ctx context.Context, tt pool.Pooler, opts Options, rebuildOpts RebuildOptions,
+	fn TransformFunc) error {
+	size := rebuildOpts.BatchSize
+	if size == 0 {
+		size = defaultBatchSize
+	}
+
+	for {
+		var entries [][]any
+
+		req := tarantool.NewSelectRequest(rebuildOpts.logName()).Context(ctx).Limit(size).
+			Iterator(tarantool.IterAll).Key([]any{})
+
+		err := tt.Do(req, opts.WriteMode).GetTyped(&entries)
+		if err != nil {
+			return fmt.Errorf("select: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		replayBatch := func(_ context.Context, entries [][]any) ([]tarantool.Request, error) {
+			return rebuildReplayRequests(rebuildOpts, fn, entries)
+		}
+
+		if err = writeBatch(ctx, tt, opts, entries, replayBatch); err != nil {
+			return err
+		}
+	}
+}
+
+// rebuildReplayRequests converts log entries {id, old, new} into writes to the shadow space.
+func rebuildReplayRequests(rebuildOpts RebuildOptions, fn TransformFunc,
+	entries [][]any) ([]tarantool.Request, error) {
+	requests := make([]tarantool.Request, 0, len(entries)*2)
+
+	for _, entry := range entries {
+		if len(entry) < 3 {
+			return nil, fmt.Errorf("wrong capture log entry: %v", entry)
+		}
+
+		if old, ok := entry[1].([]any); ok {
+			transformed, err := fn(old)
+			if err != nil {
+				return nil, err
+			}
+
+			requests = append(requests, tarantool.NewDeleteRequest(rebuildOpts.shadowName()).
+				Key(tupleKey(transformed, rebuildOpts.ShadowKeyFields)))
+		}
+
+		if tuple, ok := entry[2].([]any); ok {
+			transformed, err := fn(tuple)
+			if err != nil {
+				return nil, err
+			}
+
+			requests = append(requests, tarantool.NewReplaceRequest(rebuildOpts.shadowName()).Tuple(transformed))
+		}
+
+		requests = append(requests, tarantool.NewDeleteRequest(rebuildOpts.logName()).Key([]any{entry[0]}))
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,170 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type RebuildTestSuite struct {
+	suite.Suite
+	ctx  context.Context
+	mock *mocks.PoolerMock
+	doer test_helpers.MockDoer
+	opts RebuildOptions
+}
+
+func (suite *RebuildTestSuite) SetupTest() {
+	suite.ctx = contextWithLogger(context.Background(), SilentLogger)
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.opts = RebuildOptions{
+		Space:     "users",
+		Format:    []map[string]any{{"name": "id", "type": "string"}, {"name": "name", "type": "string"}},
+		Indexes:   []RebuildIndex{{Name: "primary", Options: map[string]any{"parts": []string{"id"}}}},
+		BatchSize: 2,
+	}
+}
+
+func (suite *RebuildTestSuite) transform(tuple []any) ([]any, error) {
+	return []any{fmt.Sprint(tuple[0]), tuple[1]}, nil
+}
+
+func (suite *RebuildTestSuite) describe(call int) RecordedRequest {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[call].Req, calls[call].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec
+}
+
+func (suite *RebuildTestSuite) TestMigrate() {
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+
+	prepare := suite.describe(0).Args.([]any)
+	assert.Equal(suite.T(), []any{"users", "users_rebuild", "users_old", "users_rebuild_log"}, prepare[:4])
+	assert.Equal(suite.T(), false, prepare[6])
+
+	replace := suite.describe(2)
+	assert.Equal(suite.T(), "IPROTO_REPLACE", replace.Type)
+	assert.Equal(suite.T(), []any{"1", "a"}, replace.Tuple)
+
+	assert.Equal(suite.T(), []any{"users", "users_rebuild", "users_old", "users_rebuild_log"},
+		suite.describe(3).Args)
+}
+
+func (suite *RebuildTestSuite) TestMigrateCaptureWrites() {
+	suite.opts.CaptureWrites = true
+	suite.opts.ShadowKeyFields = []int{0}
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{
+		{1, []interface{}{1, "a"}, []interface{}{1, "b"}},
+		{2, nil, []interface{}{2, "c"}},
+	})
+	for range 5 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+	}
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{false})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 12)
+
+	deleteShadow := suite.describe(3)
+	assert.Equal(suite.T(), "IPROTO_DELETE", deleteShadow.Type)
+	assert.Equal(suite.T(), []any{"1"}, deleteShadow.Key)
+	assert.Equal(suite.T(), []any{"1", "b"}, suite.describe(4).Tuple)
+
+	deleteLog := suite.describe(5)
+	assert.Equal(suite.T(), "IPROTO_DELETE", deleteLog.Type)
+	assert.Equal(suite.T(), []any{int8(1)}, deleteLog.Key)
+	assert.Equal(suite.T(), []any{"2", "c"}, suite.describe(6).Tuple)
+}
+
+func (suite *RebuildTestSuite) TestMigrateCaptureWritesWithoutShadowKey() {
+	suite.opts.CaptureWrites = true
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.ErrorIs(suite.T(), err, ErrWrongRebuildOptions)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *RebuildTestSuite) TestMigrateNotSettled() {
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	for range rebuildSwapAttempts {
+		suite.doer.AddResponseRaw([]interface{}{false})
+	}
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.ErrorIs(suite.T(), err, ErrRebuildNotSettled)
+}
+
+func (suite *RebuildTestSuite) TestMigrateTransformError() {
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseRaw([][]interface{}{{1, "a"}})
+
+	err := NewRebuildMigrateFunction(suite.opts, func([]any) ([]any, error) {
+		return nil, fmt.Errorf("wrong tuple")
+	})(suite.ctx, suite.mock, DefaultOptions)
+	assert.Equal(suite.T(), "copy: batch 1: transform: wrong tuple", err.Error())
+}
+
+func (suite *RebuildTestSuite) TestMigratePrepareError() {
+	suite.doer.AddResponseError(fmt.Errorf("space \"users\" does not exist"))
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.Equal(suite.T(), `prepare rebuild: space "users" does not exist`, err.Error())
+}
+
+func (suite *RebuildTestSuite) TestMigrateAlreadySwapped() {
+	suite.opts.OldSuffix = "_v1"
+	suite.doer.AddResponseError(fmt.Errorf(`space "users_v1" already exists, ` +
+		`the space is already rebuilt or "users_v1" is not dropped`))
+
+	err := NewRebuildMigrateFunction(suite.opts, suite.transform)(suite.ctx, suite.mock, DefaultOptions)
+	assert.Error(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 1)
+	assert.Equal(suite.T(), "users_v1", suite.describe(0).Args.([]any)[2])
+}
+
+func (suite *RebuildTestSuite) TestRollback() {
+	suite.opts.OldSuffix = "_v1"
+	suite.doer.AddResponseRaw([]interface{}{true})
+
+	err := NewRebuildRollbackFunction(suite.opts)(suite.ctx, suite.mock, DefaultOptions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"users", "users_rebuild", "users_v1"}, suite.describe(0).Args)
+}
+
+func (suite *RebuildTestSuite) TestRollbackError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := NewRebuildRollbackFunction(suite.opts)(suite.ctx, suite.mock, DefaultOptions)
+	assert.Equal(suite.T(), "rollback rebuild: tarantool error", err.Error())
+}
+
+func TestRebuildTestSuite(t *testing.T) {
+	suite.Run(t, new(RebuildTestSuite))
+}
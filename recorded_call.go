@@ -0,0 +1,253 @@
+package tarantool_migrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// RecordedRequest is a deterministic description of a request sent through Pooler.Do.
+type RecordedRequest struct {
+	// Type is the IPROTO request type, e.g. "IPROTO_SELECT"
+	Type string `json:"type"`
+	// Mode is the pool mode the request was sent with
+	Mode string `json:"mode"`
+	// Space is the space name or id
+	Space any `json:"space,omitempty"`
+	// Index is the index name or id
+	Index any `json:"index,omitempty"`
+	// Key is the search key of select, update and delete requests
+	Key any `json:"key,omitempty"`
+	// Tuple is the tuple of insert, replace and upsert requests
+	Tuple any `json:"tuple,omitempty"`
+	// Ops contains update and upsert operations
+	Ops any `json:"ops,omitempty"`
+	// Function is the name of a called function
+	Function string `json:"function,omitempty"`
+	// Expr is the lua body of an eval request
+	Expr string `json:"expr,omitempty"`
+	// SQL is the text of an execute request
+	SQL string `json:"sql,omitempty"`
+	// Args contains eval, call and execute arguments
+	Args any `json:"args,omitempty"`
+}
+
+// RecordedCall is a single Pooler.Do call with its response.
+type RecordedCall struct {
+	Request RecordedRequest `json:"request"`
+	// Response is the msgpack encoded response data
+	Response []byte `json:"response,omitempty"`
+	// Error is the text of a returned error
+	Error string `json:"error,omitempty"`
+}
+
+// LoadGoldenFile reads calls recorded by RecordingPooler.WriteGoldenFile.
+func LoadGoldenFile(path string) ([]RecordedCall, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read golden file: %w", err)
+	}
+
+	var calls []RecordedCall
+
+	if err = json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("decode golden file: %w", err)
+	}
+
+	return calls, nil
+}
+
+func writeGoldenFile(path string, calls []RecordedCall) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(calls); err != nil {
+		return fmt.Errorf("encode golden file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write golden file: %w", err)
+	}
+
+	return nil
+}
+
+func describeRequest(req tarantool.Request, mode pool.Mode) (RecordedRequest, error) {
+	rec := RecordedRequest{Type: req.Type().String(), Mode: poolModeName(mode)}
+
+	body, err := decodeRequestBody(req)
+	if err != nil {
+		return rec, err
+	}
+
+	for key, val := range body {
+		rec.set(req.Type(), key, normalizeRecordedValue(val))
+	}
+
+	return rec, nil
+}
+
+// decodeRequestBody encodes request body with space and index names kept as is and decodes it back.
+func decodeRequestBody(req tarantool.Request) (map[iproto.Key]any, error) {
+	var buf bytes.Buffer
+
+	if err := req.Body(namesSchemaResolver{}, msgpack.NewEncoder(&buf)); err != nil {
+		return nil, fmt.Errorf("encode request body: %w", err)
+	}
+
+	body := make(map[iproto.Key]any)
+	if buf.Len() == 0 {
+		return body, nil
+	}
+
+	dec := msgpack.NewDecoder(&buf)
+
+	l, err := dec.DecodeMapLen()
+	if err != nil {
+		return nil, fmt.Errorf("decode request body: %w", err)
+	}
+
+	for range l {
+		key, err := dec.DecodeInt()
+		if err != nil {
+			return nil, fmt.Errorf("decode request body: %w", err)
+		}
+
+		val, err := dec.DecodeInterface()
+		if err != nil {
+			return nil, fmt.Errorf("decode request body: %w", err)
+		}
+
+		body[iproto.Key(key)] = val
+	}
+
+	return body, nil
+}
+
+func (r *RecordedRequest) set(rtype iproto.Type, key iproto.Key, val any) {
+	switch key {
+	case iproto.IPROTO_SPACE_ID, iproto.IPROTO_SPACE_NAME:
+		r.Space = val
+	case iproto.IPROTO_INDEX_ID, iproto.IPROTO_INDEX_NAME:
+		r.Index = val
+	case iproto.IPROTO_KEY:
+		r.Key = val
+	case iproto.IPROTO_TUPLE:
+		if rtype == iproto.IPROTO_EVAL || rtype == iproto.IPROTO_CALL {
+			r.Args = val
+		} else {
+			r.Tuple = val
+		}
+	case iproto.IPROTO_OPS:
+		r.Ops = val
+	case iproto.IPROTO_FUNCTION_NAME:
+		r.Function, _ = val.(string)
+	case iproto.IPROTO_EXPR:
+		r.Expr, _ = val.(string)
+	case iproto.IPROTO_SQL_TEXT:
+		r.SQL, _ = val.(string)
+	case iproto.IPROTO_SQL_BIND:
+		r.Args = val
+	default:
+	}
+}
+
+// String returns JSON representation of the request.
+func (r RecordedRequest) String() string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return r.Type
+	}
+
+	return string(data)
+}
+
+func (r *RecordedRequest) equal(other RecordedRequest) bool {
+	a, errA := json.Marshal(r)
+	b, errB := json.Marshal(other)
+
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// normalizeRecordedValue converts decoded msgpack values into JSON friendly ones.
+// Extension types (datetime, decimal, uuid) are replaced by their type name,
+// so golden files stay stable between runs.
+func normalizeRecordedValue(val any) any {
+	switch v := val.(type) {
+	case nil, bool, string, float32, float64,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v
+	case []byte:
+		return string(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalizeRecordedValue(item)
+		}
+
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = normalizeRecordedValue(item)
+		}
+
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[fmt.Sprint(k)] = normalizeRecordedValue(item)
+		}
+
+		return out
+	default:
+		return fmt.Sprintf("<%T>", v)
+	}
+}
+
+// namesSchemaResolver keeps space and index names as is, so they are written into request body.
+type namesSchemaResolver struct{}
+
+func (namesSchemaResolver) ResolveSpace(s any) (uint32, error) {
+	return resolveSchemaNumber(s)
+}
+
+func (namesSchemaResolver) ResolveIndex(i any, _ uint32) (uint32, error) {
+	if i == nil {
+		return 0, nil
+	}
+
+	return resolveSchemaNumber(i)
+}
+
+func (namesSchemaResolver) NamesUseSupported() bool {
+	return true
+}
+
+func resolveSchemaNumber(v any) (uint32, error) {
+	switch n := v.(type) {
+	case uint32:
+		return n, nil
+	case uint:
+		return uint32(n), nil
+	case uint64:
+		return uint32(n), nil
+	case int:
+		return uint32(n), nil
+	case int32:
+		return uint32(n), nil
+	case int64:
+		return uint32(n), nil
+	default:
+		return 0, fmt.Errorf("unsupported schema identifier %v", v)
+	}
+}
@@ -0,0 +1,80 @@
+package tarantool_migrator
+
+import (
+	"bytes"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// recordedFuture is an already resolved tarantool.Future holding msgpack encoded response data.
+type recordedFuture struct {
+	resp *recordedResponse
+	err  error
+	done chan struct{}
+}
+
+func (f *recordedFuture) Get() ([]any, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.resp.Decode()
+}
+
+func (f *recordedFuture) GetTyped(result any) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	return f.resp.DecodeTyped(result)
+}
+
+func (f *recordedFuture) GetResponse() (tarantool.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.resp, nil
+}
+
+func (f *recordedFuture) Release() {
+}
+
+func (f *recordedFuture) WaitChan() <-chan struct{} {
+	return f.done
+}
+
+func newRecordedFuture(data []byte, err error) tarantool.Future {
+	done := make(chan struct{})
+	close(done)
+
+	return &recordedFuture{resp: &recordedResponse{data: data}, err: err, done: done}
+}
+
+type recordedResponse struct {
+	data []byte
+}
+
+func (r *recordedResponse) Header() tarantool.Header {
+	return tarantool.Header{}
+}
+
+func (r *recordedResponse) Release() {
+}
+
+func (r *recordedResponse) Decode() ([]any, error) {
+	if len(r.data) == 0 {
+		return nil, nil
+	}
+
+	return msgpack.NewDecoder(bytes.NewReader(r.data)).DecodeSlice()
+}
+
+func (r *recordedResponse) DecodeTyped(res any) error {
+	if len(r.data) == 0 {
+		return nil
+	}
+
+	return msgpack.NewDecoder(bytes.NewReader(r.data)).Decode(res)
+}
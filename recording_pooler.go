@@ -0,0 +1,79 @@
+package tarantool_migrator
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// RecordingPooler is a pool.Pooler decorator which records every Do call with its response.
+// Recorded calls can be written to a golden file and served back by ReplayPooler.
+type RecordingPooler struct {
+	pool.Pooler
+	mu    sync.Mutex
+	calls []RecordedCall
+}
+
+// Do sends the request to the underlying pooler, waits for the response and records both.
+func (rp *RecordingPooler) Do(req tarantool.Request, mode pool.Mode) tarantool.Future {
+	rec, err := describeRequest(req, mode)
+	if err != nil {
+		return newRecordedFuture(nil, err)
+	}
+
+	call := RecordedCall{Request: rec}
+
+	data, err := rp.Pooler.Do(req, mode).Get()
+	if err != nil {
+		call.Error = err.Error()
+	} else {
+		var buf bytes.Buffer
+		if err = msgpack.NewEncoder(&buf).Encode(data); err != nil {
+			return newRecordedFuture(nil, err)
+		}
+
+		call.Response = buf.Bytes()
+	}
+
+	rp.mu.Lock()
+	rp.calls = append(rp.calls, call)
+	rp.mu.Unlock()
+
+	return newRecordedFuture(call.Response, err)
+}
+
+// Calls returns a copy of the recorded calls.
+func (rp *RecordingPooler) Calls() []RecordedCall {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	calls := make([]RecordedCall, len(rp.calls))
+	copy(calls, rp.calls)
+
+	return calls
+}
+
+// Requests returns recorded requests without responses.
+func (rp *RecordingPooler) Requests() []RecordedRequest {
+	calls := rp.Calls()
+
+	requests := make([]RecordedRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+
+	return requests
+}
+
+// WriteGoldenFile writes the recorded calls to the golden file.
+func (rp *RecordingPooler) WriteGoldenFile(path string) error {
+	return writeGoldenFile(path, rp.Calls())
+}
+
+// NewRecordingPooler wraps the pooler with RecordingPooler.
+func NewRecordingPooler(tt pool.Pooler) *RecordingPooler {
+	return &RecordingPooler{Pooler: tt}
+}
@@ -0,0 +1,130 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+type RecordingPoolerTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	testable *RecordingPooler
+}
+
+func (suite *RecordingPoolerTestSuite) SetupTest() {
+	suite.mock = &mocks.PoolerMock{}
+	suite.ctx = context.Background()
+	suite.testable = NewRecordingPooler(suite.mock)
+}
+
+func (suite *RecordingPoolerTestSuite) TestDoRecordsSelect() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{{"foo"}})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	var tuples [][]string
+	err := suite.testable.Do(tarantool.NewSelectRequest("migrations").Key([]any{"qwerty"}), pool.ModeAny).
+		GetTyped(&tuples)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), [][]string{{"foo"}}, tuples)
+
+	calls := suite.testable.Calls()
+	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), "IPROTO_SELECT", calls[0].Request.Type)
+	assert.Equal(suite.T(), "any", calls[0].Request.Mode)
+	assert.Equal(suite.T(), "migrations", calls[0].Request.Space)
+	assert.Equal(suite.T(), []any{"qwerty"}, calls[0].Request.Key)
+	assert.NotEmpty(suite.T(), calls[0].Response)
+	assert.Empty(suite.T(), calls[0].Error)
+}
+
+func (suite *RecordingPoolerTestSuite) TestDoRecordsEval() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	_, err := suite.testable.Do(tarantool.NewEvalRequest("return ...").Args([]any{"foo"}), pool.ModeRW).Get()
+
+	assert.NoError(suite.T(), err)
+
+	requests := suite.testable.Requests()
+	assert.Len(suite.T(), requests, 1)
+	assert.Equal(suite.T(), "IPROTO_EVAL", requests[0].Type)
+	assert.Equal(suite.T(), "rw", requests[0].Mode)
+	assert.Equal(suite.T(), "return ...", requests[0].Expr)
+	assert.Equal(suite.T(), []any{"foo"}, requests[0].Args)
+}
+
+func (suite *RecordingPoolerTestSuite) TestDoRecordsError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	_, err := suite.testable.Do(tarantool.NewDeleteRequest("migrations").Key([]any{"qwerty"}), pool.ModeRW).Get()
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "tarantool error", err.Error())
+
+	calls := suite.testable.Calls()
+	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), "IPROTO_DELETE", calls[0].Request.Type)
+	assert.Equal(suite.T(), "tarantool error", calls[0].Error)
+	assert.Empty(suite.T(), calls[0].Response)
+}
+
+func (suite *RecordingPoolerTestSuite) TestGoldenMigrate() {
+	suite.mock.ConnectedNowFunc = func(mode pool.Mode) (bool, error) {
+		return true, nil
+	}
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	mockDoer.AddResponseRaw([][]interface{}{})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	migrator := NewMigrator(suite.testable, MigrationsCollection{
+		{ID: "migration-success", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger))
+	err := migrator.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	path := filepath.Join("testdata", "golden", "migrate_success.json")
+	if *updateGolden {
+		assert.NoError(suite.T(), suite.testable.WriteGoldenFile(path))
+	}
+
+	expected, err := LoadGoldenFile(path)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.testable.Requests(), len(expected))
+
+	for i, req := range suite.testable.Requests() {
+		assert.Equal(suite.T(), expected[i].Request.String(), req.String())
+	}
+}
+
+func TestRecordingPoolerTestSuite(t *testing.T) {
+	suite.Run(t, new(RecordingPoolerTestSuite))
+}
@@ -0,0 +1,108 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// ReplayPooler is a pool.Pooler which serves responses recorded by RecordingPooler.
+// Every Do call must match the next recorded request, otherwise ErrReplayMismatch is returned.
+type ReplayPooler struct {
+	mu    sync.Mutex
+	calls []RecordedCall
+	pos   int
+}
+
+func (rp *ReplayPooler) Do(req tarantool.Request, mode pool.Mode) tarantool.Future {
+	rec, err := describeRequest(req, mode)
+	if err != nil {
+		return newRecordedFuture(nil, err)
+	}
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	if rp.pos >= len(rp.calls) {
+		return newRecordedFuture(nil, fmt.Errorf("%w: unexpected call #%d %s",
+			ErrReplayExhausted, rp.pos+1, rec.String()))
+	}
+
+	call := rp.calls[rp.pos]
+	if !call.Request.equal(rec) {
+		return newRecordedFuture(nil, fmt.Errorf("%w: call #%d expected %s, got %s",
+			ErrReplayMismatch, rp.pos+1, call.Request.String(), rec.String()))
+	}
+
+	rp.pos++
+
+	if call.Error != "" {
+		return newRecordedFuture(nil, errors.New(call.Error))
+	}
+
+	return newRecordedFuture(call.Response, nil)
+}
+
+// Remaining returns the number of recorded calls which were not replayed yet.
+func (rp *ReplayPooler) Remaining() int {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return len(rp.calls) - rp.pos
+}
+
+func (rp *ReplayPooler) Add(_ context.Context, _ pool.Instance) error {
+	return ErrReplayUnsupported
+}
+
+func (rp *ReplayPooler) Remove(_ string) error {
+	return ErrReplayUnsupported
+}
+
+func (rp *ReplayPooler) ConnectedNow(_ pool.Mode) (bool, error) {
+	return true, nil
+}
+
+func (rp *ReplayPooler) Close() error {
+	return nil
+}
+
+func (rp *ReplayPooler) CloseGraceful() error {
+	return nil
+}
+
+func (rp *ReplayPooler) ConfiguredTimeout(_ pool.Mode) (time.Duration, error) {
+	return 0, nil
+}
+
+func (rp *ReplayPooler) NewPrepared(_ string, _ pool.Mode) (*tarantool.Prepared, error) {
+	return nil, ErrReplayUnsupported
+}
+
+func (rp *ReplayPooler) NewStream(_ pool.Mode) (*tarantool.Stream, error) {
+	return nil, ErrReplayUnsupported
+}
+
+func (rp *ReplayPooler) NewWatcher(_ string, _ tarantool.WatchCallback, _ pool.Mode) (tarantool.Watcher, error) {
+	return nil, ErrReplayUnsupported
+}
+
+// NewReplayPooler creates ReplayPooler serving the recorded calls in order.
+func NewReplayPooler(calls []RecordedCall) *ReplayPooler {
+	return &ReplayPooler{calls: calls}
+}
+
+// NewReplayPoolerFromGoldenFile creates ReplayPooler from the golden file.
+func NewReplayPoolerFromGoldenFile(path string) (*ReplayPooler, error) {
+	calls, err := LoadGoldenFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayPooler(calls), nil
+}
@@ -0,0 +1,96 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+type ReplayPoolerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *ReplayPoolerTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func (suite *ReplayPoolerTestSuite) TestDoReplaysResponse() {
+	testable := NewReplayPooler([]RecordedCall{
+		{
+			Request:  RecordedRequest{Type: "IPROTO_EVAL", Mode: "rw", Expr: "box.info", Args: []any{}},
+			Response: []byte{0x91, 0xa3, 'f', 'o', 'o'},
+		},
+	})
+
+	data, err := testable.Do(tarantool.NewEvalRequest("box.info"), pool.ModeRW).Get()
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"foo"}, data)
+	assert.Equal(suite.T(), 0, testable.Remaining())
+}
+
+func (suite *ReplayPoolerTestSuite) TestDoReplaysError() {
+	testable := NewReplayPooler([]RecordedCall{
+		{
+			Request: RecordedRequest{Type: "IPROTO_EVAL", Mode: "rw", Expr: "box.info", Args: []any{}},
+			Error:   "tarantool error",
+		},
+	})
+
+	_, err := testable.Do(tarantool.NewEvalRequest("box.info"), pool.ModeRW).Get()
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "tarantool error", err.Error())
+}
+
+func (suite *ReplayPoolerTestSuite) TestDoMismatch() {
+	testable := NewReplayPooler([]RecordedCall{
+		{Request: RecordedRequest{Type: "IPROTO_EVAL", Mode: "rw", Expr: "box.info", Args: []any{}}},
+	})
+
+	_, err := testable.Do(tarantool.NewEvalRequest("box.cfg"), pool.ModeRW).Get()
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), errors.Is(err, ErrReplayMismatch))
+	assert.Equal(suite.T(), 1, testable.Remaining())
+}
+
+func (suite *ReplayPoolerTestSuite) TestDoExhausted() {
+	testable := NewReplayPooler(nil)
+
+	_, err := testable.Do(tarantool.NewEvalRequest("box.info"), pool.ModeRW).Get()
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), errors.Is(err, ErrReplayExhausted))
+}
+
+func (suite *ReplayPoolerTestSuite) TestLoadGoldenFileNotFound() {
+	testable, err := NewReplayPoolerFromGoldenFile(filepath.Join("testdata", "golden", "not_found.json"))
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), testable)
+}
+
+func (suite *ReplayPoolerTestSuite) TestGoldenMigrate() {
+	testable, err := NewReplayPoolerFromGoldenFile(filepath.Join("testdata", "golden", "migrate_success.json"))
+	assert.NoError(suite.T(), err)
+
+	migrator := NewMigrator(testable, MigrationsCollection{
+		{ID: "migration-success", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger))
+	err = migrator.Migrate(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, testable.Remaining())
+}
+
+func TestReplayPoolerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayPoolerTestSuite))
+}
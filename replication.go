@@ -0,0 +1,71 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReplicationWait defines when the migrator waits for replicas to catch up with the master.
+type ReplicationWait int
+
+const (
+	// ReplicationWaitNone disables waiting for replicas
+	ReplicationWaitNone ReplicationWait = iota
+	// ReplicationWaitEachMigration waits after every applied or rolled back migration
+	ReplicationWaitEachMigration
+	// ReplicationWaitRun waits once after the whole run when something was applied
+	ReplicationWaitRun
+)
+
+const defaultReplicationPollInterval = 100 * time.Millisecond
+
+// replicationState is the master vclock to reach and names of replicas which have not reached it yet.
+type replicationState struct {
+	_msgpack struct{} `msgpack:",as_array"` //nolint:unused
+	// Vclock contains {replica id, lsn} pairs of the master vclock
+	Vclock [][]uint64
+	// Lagging contains names or uuids of lagging replicas
+	Lagging []string
+}
+
+// waitReplication polls the master until every replica reaches its vclock taken at the first poll.
+func (m *Migrator) waitReplication(ctx context.Context) error {
+	if m.opts.DryRun {
+		return nil
+	}
+
+	if m.opts.ReplicationTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, m.opts.ReplicationTimeout)
+		defer cancel()
+	}
+
+	interval := m.opts.ReplicationPollInterval
+	if interval <= 0 {
+		interval = defaultReplicationPollInterval
+	}
+
+	state, err := m.ex.replicationState(ctx, nil)
+
+	for err == nil && len(state.Lagging) > 0 {
+		m.logger.DebugContext(ctx, "waiting for replicas", "lagging", state.Lagging)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v: lagging replicas: %s",
+				ErrReplicationLag, ctx.Err(), strings.Join(state.Lagging, ", "))
+		case <-time.After(interval):
+		}
+
+		state, err = m.ex.replicationState(ctx, state.Vclock)
+	}
+
+	if err != nil {
+		return fmt.Errorf("wait replication: %w", err)
+	}
+
+	return nil
+}
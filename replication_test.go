@@ -0,0 +1,132 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type ReplicationTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *ReplicationTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger), WithOptions(&Options{
+		MigrationsSpace:         "migrations",
+		ReadMode:                pool.ModeAny,
+		WriteMode:               pool.ModeRW,
+		ReplicationPollInterval: time.Millisecond,
+	}))
+}
+
+func (suite *ReplicationTestSuite) addMigrateResponses() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+}
+
+func (suite *ReplicationTestSuite) addReplicationResponse(lagging ...string) {
+	suite.doer.AddResponseRaw([]interface{}{[][]uint64{{1, 10}}, lagging})
+}
+
+func (suite *ReplicationTestSuite) TestWaitReplicationSynced() {
+	suite.addReplicationResponse("replica-2")
+	suite.addReplicationResponse()
+
+	err := suite.testable.waitReplication(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 2)
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+
+	first, err := describeRequest(calls[0].Req, calls[0].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{}, first.Args)
+
+	second, err := describeRequest(calls[1].Req, calls[1].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{[]any{[]any{uint64(1), uint64(10)}}}, second.Args)
+}
+
+func (suite *ReplicationTestSuite) TestWaitReplicationTimeout() {
+	for range 1000 {
+		suite.addReplicationResponse("replica-2", "replica-3")
+	}
+	suite.testable.opts.ReplicationTimeout = 10 * time.Millisecond
+
+	err := suite.testable.waitReplication(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrReplicationLag)
+	assert.Contains(suite.T(), err.Error(), "lagging replicas: replica-2, replica-3")
+}
+
+func (suite *ReplicationTestSuite) TestWaitReplicationInDryRunMode() {
+	suite.testable.opts.DryRun = true
+
+	err := suite.testable.waitReplication(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *ReplicationTestSuite) TestMigrateWaitEachMigration() {
+	suite.testable.opts.WaitReplication = ReplicationWaitEachMigration
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.addMigrateResponses()
+	suite.addReplicationResponse()
+	suite.addMigrateResponses()
+	suite.addReplicationResponse()
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 9)
+}
+
+func (suite *ReplicationTestSuite) TestMigrateWaitRun() {
+	suite.testable.opts.WaitReplication = ReplicationWaitRun
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.addMigrateResponses()
+	suite.addMigrateResponses()
+	suite.addReplicationResponse()
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 8)
+}
+
+func (suite *ReplicationTestSuite) TestMigrateWaitRunError() {
+	suite.testable.opts.WaitReplication = ReplicationWaitRun
+	suite.testable.opts.ReplicationTimeout = time.Millisecond
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.addMigrateResponses()
+	suite.addMigrateResponses()
+	for range 1000 {
+		suite.addReplicationResponse("replica-2")
+	}
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrReplicationLag)
+}
+
+func TestReplicationTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplicationTestSuite))
+}
@@ -0,0 +1,140 @@
+package tarantool_migrator
+
+import "time"
+
+// MigrationAction describes what happened to a migration during the run.
+type MigrationAction string
+
+const (
+	// MigrationActionApplied means the migration was migrated
+	MigrationActionApplied MigrationAction = "applied"
+	// MigrationActionRolledBack means the migration was rolled back
+	MigrationActionRolledBack MigrationAction = "rolled-back"
+	// MigrationActionSkipped means the migration was skipped by VersionPolicySkip, it stays pending
+	MigrationActionSkipped MigrationAction = "skipped"
+	// MigrationActionAlreadyApplied means the migration was found in the store
+	MigrationActionAlreadyApplied MigrationAction = "already-applied"
+	// MigrationActionFailed means the migration failed, the run is stopped
+	MigrationActionFailed MigrationAction = "failed"
+	// MigrationActionDryRun means the migration would be migrated or rolled back without Options.DryRun
+	MigrationActionDryRun MigrationAction = "dry-run"
+)
+
+// MigrationResult is the outcome of a single migration considered by the run.
+type MigrationResult struct {
+	// ID of the migration
+	ID string `json:"id"`
+	// Action taken
+	Action MigrationAction `json:"action"`
+	// StartedAt is the time the migration was considered
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is the time the action was completed
+	FinishedAt time.Time `json:"finished_at"`
+	// Duration of the action
+	Duration time.Duration `json:"duration"`
+	// Err is the migration error when Action is MigrationActionFailed
+	Err error `json:"-"`
+	// Error is the text of Err, so the result can be marshaled
+	Error string `json:"error,omitempty"`
+}
+
+// ResultTotals counts migrations of the run by action.
+type ResultTotals struct {
+	Total          int `json:"total"`
+	Applied        int `json:"applied"`
+	RolledBack     int `json:"rolled_back"`
+	Skipped        int `json:"skipped"`
+	AlreadyApplied int `json:"already_applied"`
+	Failed         int `json:"failed"`
+	DryRun         int `json:"dry_run"`
+}
+
+// Result describes the run of Migrate or RollbackLast command.
+type Result struct {
+	// Direction is MigrationDirectionUp for Migrate and MigrationDirectionDown for RollbackLast
+	Direction string `json:"direction"`
+	// Migrations in order they were considered, migrations after the failed one are not listed
+	Migrations []MigrationResult `json:"migrations"`
+	// Totals of Migrations
+	Totals ResultTotals `json:"totals"`
+	// StartedAt is the start time of the run
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is the end time of the run
+	FinishedAt time.Time `json:"finished_at"`
+	// Duration of the run
+	Duration time.Duration `json:"duration"`
+	// Err is the error of the run, it can happen outside any migration, e.g. in preflight checks
+	Err error `json:"-"`
+	// Error is the text of Err, so the result can be marshaled
+	Error string `json:"error,omitempty"`
+}
+
+func newResult(direction string) *Result {
+	return &Result{Direction: direction, StartedAt: time.Now().UTC()}
+}
+
+// add records the action of the migration considered since startedAt.
+func (r *Result) add(id string, action MigrationAction, startedAt time.Time, err error) {
+	if err != nil {
+		action = MigrationActionFailed
+	}
+
+	finishedAt := time.Now().UTC()
+	r.Migrations = append(r.Migrations, MigrationResult{
+		ID:         id,
+		Action:     action,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		Duration:   finishedAt.Sub(startedAt),
+		Err:        err,
+		Error:      errorText(err),
+	})
+}
+
+// ids returns IDs of migrations with the given actions.
+func (r *Result) ids(actions ...MigrationAction) []string {
+	var ids []string
+
+	for _, migration := range r.Migrations {
+		for _, action := range actions {
+			if migration.Action == action {
+				ids = append(ids, migration.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+func (r *Result) finish(err error) {
+	r.Err = err
+	r.Error = errorText(err)
+	r.FinishedAt = time.Now().UTC()
+	r.Duration = r.FinishedAt.Sub(r.StartedAt)
+	r.Totals = ResultTotals{Total: len(r.Migrations)}
+
+	for _, migration := range r.Migrations {
+		switch migration.Action {
+		case MigrationActionApplied:
+			r.Totals.Applied++
+		case MigrationActionRolledBack:
+			r.Totals.RolledBack++
+		case MigrationActionSkipped:
+			r.Totals.Skipped++
+		case MigrationActionAlreadyApplied:
+			r.Totals.AlreadyApplied++
+		case MigrationActionFailed:
+			r.Totals.Failed++
+		case MigrationActionDryRun:
+			r.Totals.DryRun++
+		}
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,63 @@
+package tarantool_migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultFinish(t *testing.T) {
+	result := newResult(MigrationDirectionUp)
+	startedAt := time.Now().UTC()
+	result.add("migration-1", MigrationActionAlreadyApplied, startedAt, nil)
+	result.add("migration-2", MigrationActionApplied, startedAt, nil)
+	result.add("migration-3", MigrationActionSkipped, startedAt, nil)
+	result.add("migration-4", MigrationActionApplied, startedAt, fmt.Errorf("tarantool error"))
+	result.finish(fmt.Errorf("run error"))
+
+	assert.Equal(t, ResultTotals{Total: 4, Applied: 1, Skipped: 1, AlreadyApplied: 1, Failed: 1}, result.Totals)
+	assert.Equal(t, MigrationActionFailed, result.Migrations[3].Action)
+	assert.Equal(t, "tarantool error", result.Migrations[3].Err.Error())
+	assert.Equal(t, "run error", result.Err.Error())
+	assert.False(t, result.FinishedAt.Before(result.StartedAt))
+	assert.Equal(t, result.FinishedAt.Sub(result.StartedAt), result.Duration)
+	assert.Equal(t, result.Migrations[1].FinishedAt.Sub(startedAt), result.Migrations[1].Duration)
+}
+
+func TestResultIDs(t *testing.T) {
+	result := newResult(MigrationDirectionUp)
+	result.add("migration-1", MigrationActionAlreadyApplied, time.Now(), nil)
+	result.add("migration-2", MigrationActionApplied, time.Now(), nil)
+	result.add("migration-3", MigrationActionDryRun, time.Now(), nil)
+
+	assert.Equal(t, []string{"migration-2", "migration-3"}, result.ids(MigrationActionApplied, MigrationActionDryRun))
+	assert.Nil(t, result.ids(MigrationActionFailed))
+}
+
+func TestResultJSON(t *testing.T) {
+	startedAt := time.Date(2024, 10, 8, 23, 45, 0, 0, time.UTC)
+	result := &Result{Direction: MigrationDirectionUp, StartedAt: startedAt}
+	result.add("migration-1", MigrationActionApplied, startedAt, fmt.Errorf("tarantool error"))
+	result.finish(fmt.Errorf("run error"))
+
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "run error", decoded["error"])
+	assert.Equal(t, "up", decoded["direction"])
+	assert.Equal(t, map[string]any{
+		"total": 1.0, "applied": 0.0, "rolled_back": 0.0, "skipped": 0.0, "already_applied": 0.0, "failed": 1.0,
+		"dry_run": 0.0,
+	}, decoded["totals"])
+
+	migration := decoded["migrations"].([]any)[0].(map[string]any)
+	assert.Equal(t, "migration-1", migration["id"])
+	assert.Equal(t, "failed", migration["action"])
+	assert.Equal(t, "tarantool error", migration["error"])
+	assert.Equal(t, "2024-10-08T23:45:00Z", migration["started_at"])
+}
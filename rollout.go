@@ -0,0 +1,257 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+// RolloutPolicy defines behavior of Rollout when migration of an instance fails.
+type RolloutPolicy int
+
+const (
+	// RolloutFailFast stops rollout on the first failed instance
+	RolloutFailFast RolloutPolicy = iota
+	// RolloutContinueOnError migrates all instances and returns joined errors
+	RolloutContinueOnError
+)
+
+// InstanceResult is the result of migrate command on a single instance.
+type InstanceResult struct {
+	// Instance is the name of pool instance
+	Instance string
+	// Err is the migrate error or nil
+	Err error
+	// Duration of migrate command on the instance
+	Duration time.Duration
+	// Result describes migrations of the instance
+	Result *Result
+}
+
+// InstanceStatus is the migrations status of a single instance.
+type InstanceStatus struct {
+	// Instance is the name of pool instance or vshard replicaset
+	Instance string
+	// Status is nil when Err is not nil
+	Status *MigrationsStatus
+	// Err is the status error or nil
+	Err error
+}
+
+// IsLagging returns true when instance has pending migrations or its status is unknown.
+func (s *InstanceStatus) IsLagging() bool {
+	return s.Err != nil || !s.Status.IsUpToDate()
+}
+
+// ClusterStatus is the migrations status of all rollout targets.
+type ClusterStatus []InstanceStatus
+
+// Lagging returns instances which are behind defined migrations.
+func (cs ClusterStatus) Lagging() []InstanceStatus {
+	var lagging []InstanceStatus
+
+	for _, status := range cs {
+		if status.IsLagging() {
+			lagging = append(lagging, status)
+		}
+	}
+
+	return lagging
+}
+
+// rolloutTargets lists instances of the rollout and creates poolers sending requests to them.
+type rolloutTargets interface {
+	list(ctx context.Context) ([]string, error)
+	pooler(name string) pool.Pooler
+}
+
+// mastersTargets are connected writable instances of the pool.
+type mastersTargets struct {
+	tt InstancesPooler
+}
+
+func (t *mastersTargets) list(_ context.Context) ([]string, error) {
+	var names []string
+
+	for name, info := range t.tt.Info() {
+		if info.ConnectedNow && info.Role == pool.RoleMaster {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (t *mastersTargets) pooler(name string) pool.Pooler {
+	return newInstancePooler(t.tt, name)
+}
+
+// Rollout applies migrations to every writable master of the pool, e.g. to independent replicasets.
+// Every master keeps its own migrations space.
+type Rollout struct {
+	targets    rolloutTargets
+	migrations MigrationsCollection
+	policy     RolloutPolicy
+	logger     *slog.Logger
+	options    []func(*Migrator)
+}
+
+func NewRollout(tt InstancesPooler, migrations MigrationsCollection, options ...func(*Rollout)) *Rollout {
+	r := &Rollout{
+		targets:    &mastersTargets{tt: tt},
+		migrations: migrations,
+		policy:     RolloutFailFast,
+		logger:     DefaultLogger,
+	}
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
+// NewVshardRollout creates Rollout applying migrations to every replicaset known to the vshard router.
+// Requests are forwarded to replicaset masters through the router, which must be able to eval on storages.
+func NewVshardRollout(router pool.Pooler, migrations MigrationsCollection, options ...func(*Rollout)) *Rollout {
+	r := &Rollout{
+		targets:    &vshardTargets{router: router},
+		migrations: migrations,
+		policy:     RolloutFailFast,
+		logger:     DefaultLogger,
+	}
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
+// Targets returns sorted names of rollout instances.
+func (r *Rollout) Targets(ctx context.Context) ([]string, error) {
+	targets, err := r.targets.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list rollout targets: %w", err)
+	}
+
+	if len(targets) == 0 {
+		return nil, ErrNoWritableInstances
+	}
+
+	return targets, nil
+}
+
+// Masters returns sorted names of rollout instances like Targets, nil when they can't be listed.
+//
+// Deprecated: use Targets, it reports the error.
+func (r *Rollout) Masters() []string {
+	targets, err := r.Targets(context.Background())
+	if err != nil {
+		return nil
+	}
+
+	return targets
+}
+
+func (r *Rollout) Migrate(ctx context.Context) ([]InstanceResult, error) {
+	return r.run(ctx, "migrate", func(ctx context.Context, m *Migrator) (*Result, error) {
+		return m.MigrateWithResult(ctx)
+	})
+}
+
+func (r *Rollout) RollbackLast(ctx context.Context) ([]InstanceResult, error) {
+	return r.run(ctx, "rollback-last", func(ctx context.Context, m *Migrator) (*Result, error) {
+		return m.RollbackLastWithResult(ctx)
+	})
+}
+
+func (r *Rollout) run(
+	ctx context.Context, cmd string, fn func(context.Context, *Migrator) (*Result, error),
+) ([]InstanceResult, error) {
+	masters, err := r.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "started rollout", "command", cmd, "instances", masters)
+
+	results := make([]InstanceResult, 0, len(masters))
+
+	var errs []error
+
+	for _, name := range masters {
+		r.logger.InfoContext(ctx, "rollout instance started", "command", cmd, "instance", name)
+
+		migrator := r.newMigrator(name)
+
+		startedAt := time.Now().UTC()
+		res, err := fn(ctx, migrator)
+		result := InstanceResult{Instance: name, Err: err, Duration: time.Now().UTC().Sub(startedAt), Result: res}
+		results = append(results, result)
+
+		if err == nil {
+			r.logger.InfoContext(ctx, "rollout instance finished", "command", cmd, "instance", name,
+				"duration_ms", formatDurationToMs(result.Duration))
+
+			continue
+		}
+
+		err = fmt.Errorf(`instance "%s" error: %w`, name, err)
+		if r.policy == RolloutFailFast {
+			return results, err
+		}
+
+		r.logger.ErrorContext(ctx, "rollout instance failed", "command", cmd, "instance", name, "error", err)
+		errs = append(errs, err)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// Status returns migrations status of every rollout instance.
+func (r *Rollout) Status(ctx context.Context) (ClusterStatus, error) {
+	targets, err := r.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(ClusterStatus, 0, len(targets))
+
+	for _, name := range targets {
+		st, err := r.newMigrator(name).Status(ctx)
+		status = append(status, InstanceStatus{Instance: name, Status: st, Err: err})
+	}
+
+	return status, nil
+}
+
+func (r *Rollout) newMigrator(name string) *Migrator {
+	options := append([]func(*Migrator){WithLogger(r.logger.With("instance", name))}, r.options...)
+
+	return NewMigrator(r.targets.pooler(name), r.migrations, options...)
+}
+
+func WithRolloutPolicy(policy RolloutPolicy) func(rollout *Rollout) {
+	return func(r *Rollout) {
+		r.policy = policy
+	}
+}
+
+func WithRolloutLogger(lg *slog.Logger) func(rollout *Rollout) {
+	return func(r *Rollout) {
+		r.logger = lg
+	}
+}
+
+// WithRolloutMigratorOptions sets options of migrators created for every instance.
+func WithRolloutMigratorOptions(options ...func(*Migrator)) func(rollout *Rollout) {
+	return func(r *Rollout) {
+		r.options = options
+	}
+}
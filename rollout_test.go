@@ -0,0 +1,156 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+type RolloutTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	stub       *instancesPoolerStub
+	migrations MigrationsCollection
+}
+
+func (suite *RolloutTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.stub = newInstancesPoolerStub(suite.T(), map[string]pool.Role{
+		"storage-2-a": pool.RoleMaster,
+		"storage-1-a": pool.RoleMaster,
+		"storage-1-b": pool.RoleReplica,
+	})
+	suite.migrations = MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+	}
+}
+
+func (suite *RolloutTestSuite) addSuccessResponses(name string) {
+	suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+	suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+	suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+	suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+}
+
+func (suite *RolloutTestSuite) TestTargets() {
+	disconnected := suite.stub.info["storage-2-a"]
+	disconnected.ConnectedNow = false
+	suite.stub.info["storage-2-a"] = disconnected
+
+	testable := NewRollout(suite.stub, suite.migrations)
+	targets, err := testable.Targets(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"storage-1-a"}, targets)
+}
+
+func (suite *RolloutTestSuite) TestMasters() {
+	testable := NewRollout(suite.stub, suite.migrations)
+	assert.Equal(suite.T(), []string{"storage-1-a", "storage-2-a"}, testable.Masters())
+
+	suite.stub.info = map[string]pool.Info{}
+	assert.Nil(suite.T(), testable.Masters())
+}
+
+func (suite *RolloutTestSuite) TestMigrateWithoutMasters() {
+	suite.stub.info = map[string]pool.Info{}
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger))
+	results, err := testable.Migrate(suite.ctx)
+	assert.Nil(suite.T(), results)
+	assert.ErrorIs(suite.T(), err, ErrNoWritableInstances)
+}
+
+func (suite *RolloutTestSuite) TestMigrateSuccess() {
+	suite.addSuccessResponses("storage-1-a")
+	suite.addSuccessResponses("storage-2-a")
+
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger),
+		WithRolloutMigratorOptions(WithLogger(SilentLogger)))
+	results, err := testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), "storage-1-a", results[0].Instance)
+	assert.NoError(suite.T(), results[0].Err)
+	assert.Equal(suite.T(), "storage-2-a", results[1].Instance)
+	assert.NoError(suite.T(), results[1].Err)
+	assert.Equal(suite.T(), MigrationDirectionUp, results[1].Result.Direction)
+	assert.Equal(suite.T(), len(suite.migrations), results[1].Result.Totals.Applied)
+	assert.Equal(suite.T(), []string{
+		"storage-1-a", "storage-1-a", "storage-1-a", "storage-1-a",
+		"storage-2-a", "storage-2-a", "storage-2-a", "storage-2-a",
+	}, suite.stub.calls)
+}
+
+func (suite *RolloutTestSuite) TestMigrateFailFast() {
+	suite.stub.doers["storage-1-a"].AddResponseError(fmt.Errorf("tarantool error"))
+
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger),
+		WithRolloutMigratorOptions(WithLogger(SilentLogger)))
+	results, err := testable.Migrate(suite.ctx)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `instance "storage-1-a" error: init migrations space error: `+
+		`exec create migrations space: tarantool error`, err.Error())
+	assert.Len(suite.T(), results, 1)
+	assert.Equal(suite.T(), []string{"storage-1-a"}, suite.stub.calls)
+}
+
+func (suite *RolloutTestSuite) TestMigrateContinueOnError() {
+	suite.stub.doers["storage-1-a"].AddResponseError(fmt.Errorf("tarantool error"))
+	suite.addSuccessResponses("storage-2-a")
+
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger),
+		WithRolloutPolicy(RolloutContinueOnError), WithRolloutMigratorOptions(WithLogger(SilentLogger)))
+	results, err := testable.Migrate(suite.ctx)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), `instance "storage-1-a" error: init migrations space error: `+
+		`exec create migrations space: tarantool error`, err.Error())
+	assert.Len(suite.T(), results, 2)
+	assert.Error(suite.T(), results[0].Err)
+	assert.NoError(suite.T(), results[1].Err)
+	assert.Len(suite.T(), suite.stub.calls, 5)
+}
+
+func (suite *RolloutTestSuite) TestRollbackLastSuccess() {
+	for _, name := range []string{"storage-1-a", "storage-2-a"} {
+		body := newMigrationTupleStubResponseBody()
+		body[0][0] = "migration-1"
+		suite.stub.doers[name].AddResponseRaw(body)
+		suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+		suite.stub.doers[name].AddResponseRaw([][]interface{}{})
+	}
+	suite.migrations[0].Rollback = NewGenericMigrateFunction("box.info")
+
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger),
+		WithRolloutMigratorOptions(WithLogger(SilentLogger)))
+	results, err := testable.RollbackLast(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 2)
+	assert.Len(suite.T(), suite.stub.calls, 6)
+}
+
+func (suite *RolloutTestSuite) TestStatus() {
+	body := newMigrationTupleStubResponseBody()
+	body[0][0] = "migration-1"
+	suite.stub.doers["storage-1-a"].AddResponseRaw([][][]interface{}{body})
+	suite.stub.doers["storage-1-a"].AddResponseRaw([][]interface{}{{}})
+	suite.stub.doers["storage-2-a"].AddResponseError(fmt.Errorf("tarantool error"))
+
+	testable := NewRollout(suite.stub, suite.migrations, WithRolloutLogger(SilentLogger))
+	status, err := testable.Status(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), status, 2)
+	assert.True(suite.T(), status[0].Status.IsUpToDate())
+	assert.False(suite.T(), status[0].IsLagging())
+	assert.Error(suite.T(), status[1].Err)
+
+	lagging := status.Lagging()
+	assert.Len(suite.T(), lagging, 1)
+	assert.Equal(suite.T(), "storage-2-a", lagging[0].Instance)
+}
+
+func TestRolloutTestSuite(t *testing.T) {
+	suite.Run(t, new(RolloutTestSuite))
+}
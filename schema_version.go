@@ -0,0 +1,121 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// DefaultSchemaVersionKey is the conventional key of SchemaVersion, Options.SchemaVersionKey is empty by default.
+const DefaultSchemaVersionKey = "tarantool_migrator.schema_version"
+
+// SchemaVersion is stored by the migrator after every applied or rolled back migration.
+type SchemaVersion struct {
+	// ID is the ID of the applied or rolled back migration
+	ID string `msgpack:"id" json:"id"`
+	// Direction is MigrationDirectionUp or MigrationDirectionDown
+	Direction string `msgpack:"direction" json:"direction"`
+	// Batch contains IDs of migrations applied or rolled back by the run so far, ID is the last of them
+	Batch []string `msgpack:"batch" json:"batch"`
+}
+
+// SchemaVersionCallback is called with every published schema version.
+type SchemaVersionCallback func(version SchemaVersion)
+
+// WatchSchemaVersion subscribes to the schema version stored under Options.SchemaVersionKey in Options.StateSpace.
+// The state space is replicated, so every instance can publish it: the function installs a trigger which
+// box.broadcast-s the stored version on every connected instance of InstancesPooler (otherwise on the instance
+// selected by mode) and subscribes to it in the mode. Triggers are lost on restart, call the function again then.
+// The callback is not called until the first version is stored. Close the watcher to unsubscribe.
+func WatchSchemaVersion(ctx context.Context, tt pool.Pooler, opts Options, mode pool.Mode,
+	callback SchemaVersionCallback) (tarantool.Watcher, error) {
+	if opts.SchemaVersionKey == "" {
+		return nil, fmt.Errorf("watch schema version: %w", ErrSchemaVersionDisabled)
+	}
+
+	if err := publishSchemaVersion(ctx, tt, opts, mode); err != nil {
+		return nil, fmt.Errorf("watch schema version: %w", err)
+	}
+
+	watcher, err := tt.NewWatcher(opts.SchemaVersionKey, func(event tarantool.WatchEvent) {
+		if event.Value == nil {
+			return
+		}
+
+		version, err := decodeSchemaVersion(event.Value)
+		if err == nil {
+			callback(version)
+		}
+	}, mode)
+	if err != nil {
+		return nil, fmt.Errorf("watch schema version: %w", err)
+	}
+
+	return watcher, nil
+}
+
+// publishSchemaVersion installs the trigger broadcasting the stored schema version on instances.
+func publishSchemaVersion(ctx context.Context, tt pool.Pooler, opts Options, mode pool.Mode) error {
+	data, err := LuaFs.ReadFile(publishSchemaVersionPath)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{opts.StateSpace, opts.SchemaVersionKey})
+
+	instances, ok := tt.(InstancesPooler)
+	if !ok {
+		if _, err = tt.Do(req, mode).Get(); err != nil {
+			return fmt.Errorf("publish schema version: %w", err)
+		}
+
+		return nil
+	}
+
+	var errs []error
+
+	for name, info := range instances.Info() {
+		if !info.ConnectedNow {
+			continue
+		}
+
+		if _, err = instances.DoOn(req, name).Get(); err != nil {
+			errs = append(errs, fmt.Errorf("publish schema version on %q: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func decodeSchemaVersion(value any) (SchemaVersion, error) {
+	var version SchemaVersion
+
+	data, err := msgpack.Marshal(value)
+	if err != nil {
+		return version, fmt.Errorf("encode schema version: %w", err)
+	}
+
+	if err = msgpack.Unmarshal(data, &version); err != nil {
+		return version, fmt.Errorf("decode schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// storeSchemaVersion stores the version of the migration for watchers. Failures are logged only,
+// because the migration itself is already applied.
+func (m *Migrator) storeSchemaVersion(ctx context.Context, id string, direction string, batch []string) {
+	if m.opts.DryRun || m.opts.SchemaVersionKey == "" {
+		return
+	}
+
+	version := SchemaVersion{ID: id, Direction: direction, Batch: batch}
+
+	if err := m.ex.storeSchemaVersion(ctx, version); err != nil {
+		m.logger.WarnContext(ctx, "schema version store failed", "id", id, "error", err)
+	}
+}
@@ -0,0 +1,181 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type SchemaVersionTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *SchemaVersionTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info")},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info")},
+	}, WithLogger(SilentLogger))
+	suite.testable.opts.SchemaVersionKey = DefaultSchemaVersionKey
+}
+
+func (suite *SchemaVersionTestSuite) TestMigrateStoresVersion() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	for range 2 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+		suite.doer.AddResponseRaw([][]interface{}{})
+		suite.doer.AddResponseRaw([][]interface{}{})
+		suite.doer.AddResponseRaw([]interface{}{true})
+	}
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 9)
+	assert.Equal(suite.T(), pool.ModeRW, calls[8].Mode)
+
+	rec, err := describeRequest(calls[8].Req, calls[8].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultSchemaVersionKey, map[string]any{
+		"id":        "migration-2",
+		"direction": "up",
+		"batch":     []any{"migration-1", "migration-2"},
+	}, false}, rec.Args)
+}
+
+func (suite *SchemaVersionTestSuite) TestMigrateStoreErrorIsIgnored() {
+	suite.testable.migrations = suite.testable.migrations[:1]
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 5)
+}
+
+func (suite *SchemaVersionTestSuite) TestMigrateWithoutKey() {
+	suite.testable.migrations = suite.testable.migrations[:1]
+	suite.testable.opts.SchemaVersionKey = ""
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 4)
+}
+
+func (suite *SchemaVersionTestSuite) TestDefaultOptionsDisableSchemaVersion() {
+	assert.Empty(suite.T(), DefaultOptions.SchemaVersionKey)
+}
+
+func (suite *SchemaVersionTestSuite) TestWatchSchemaVersion() {
+	var callback tarantool.WatchCallback
+
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.mock.NewWatcherFunc = func(key string, cb tarantool.WatchCallback, mode pool.Mode) (tarantool.Watcher, error) {
+		assert.Equal(suite.T(), DefaultSchemaVersionKey, key)
+		assert.Equal(suite.T(), pool.ModeRW, mode)
+		callback = cb
+
+		return nil, nil
+	}
+
+	var versions []SchemaVersion
+
+	_, err := WatchSchemaVersion(suite.ctx, suite.mock, *suite.testable.opts, pool.ModeRW,
+		func(version SchemaVersion) {
+			versions = append(versions, version)
+		})
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+
+	rec, err := describeRequest(calls[0].Req, calls[0].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{DefaultStateSpace, DefaultSchemaVersionKey}, rec.Args)
+
+	callback(tarantool.WatchEvent{Key: DefaultSchemaVersionKey})
+	callback(tarantool.WatchEvent{Key: DefaultSchemaVersionKey, Value: map[string]any{
+		"id": "migration-1", "direction": "down", "batch": []any{"migration-1"},
+	}})
+
+	assert.Equal(suite.T(), []SchemaVersion{
+		{ID: "migration-1", Direction: MigrationDirectionDown, Batch: []string{"migration-1"}},
+	}, versions)
+}
+
+func (suite *SchemaVersionTestSuite) TestWatchSchemaVersionOnInstances() {
+	stub := newInstancesPoolerStub(suite.T(), map[string]pool.Role{
+		"storage-1-a": pool.RoleMaster,
+		"storage-1-b": pool.RoleReplica,
+		"storage-2-a": pool.RoleMaster,
+	})
+	disconnected := stub.info["storage-2-a"]
+	disconnected.ConnectedNow = false
+	stub.info["storage-2-a"] = disconnected
+	stub.doers["storage-1-a"].AddResponseRaw([]interface{}{true})
+	stub.doers["storage-1-b"].AddResponseRaw([]interface{}{true})
+	stub.NewWatcherFunc = func(key string, cb tarantool.WatchCallback, mode pool.Mode) (tarantool.Watcher, error) {
+		return nil, nil
+	}
+
+	_, err := WatchSchemaVersion(suite.ctx, stub, *suite.testable.opts, pool.ModeAny, func(SchemaVersion) {})
+	assert.NoError(suite.T(), err)
+	assert.ElementsMatch(suite.T(), []string{"storage-1-a", "storage-1-b"}, stub.calls)
+	assert.Len(suite.T(), stub.NewWatcherCalls(), 1)
+}
+
+func (suite *SchemaVersionTestSuite) TestWatchSchemaVersionWithoutKey() {
+	watcher, err := WatchSchemaVersion(suite.ctx, suite.mock, DefaultOptions, pool.ModeRW, func(SchemaVersion) {})
+	assert.Nil(suite.T(), watcher)
+	assert.ErrorIs(suite.T(), err, ErrSchemaVersionDisabled)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *SchemaVersionTestSuite) TestWatchSchemaVersionPublishError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	watcher, err := WatchSchemaVersion(suite.ctx, suite.mock, *suite.testable.opts, pool.ModeRW, func(SchemaVersion) {})
+	assert.Nil(suite.T(), watcher)
+	assert.Equal(suite.T(), "watch schema version: publish schema version: tarantool error", err.Error())
+	assert.Empty(suite.T(), suite.mock.NewWatcherCalls())
+}
+
+func (suite *SchemaVersionTestSuite) TestWatchSchemaVersionError() {
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.mock.NewWatcherFunc = func(key string, cb tarantool.WatchCallback, mode pool.Mode) (tarantool.Watcher, error) {
+		return nil, fmt.Errorf("watchers are not supported")
+	}
+
+	watcher, err := WatchSchemaVersion(suite.ctx, suite.mock, *suite.testable.opts, pool.ModeRW, func(SchemaVersion) {})
+	assert.Nil(suite.T(), watcher)
+	assert.Equal(suite.T(), "watch schema version: watchers are not supported", err.Error())
+}
+
+func TestSchemaVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaVersionTestSuite))
+}
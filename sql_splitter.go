@@ -0,0 +1,133 @@
+package tarantool_migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitSQLStatements splits SQL script into statements separated by ";".
+// Separators inside string literals, quoted identifiers, comments and CREATE TRIGGER bodies are ignored.
+// Comments are replaced by a single space, empty statements are skipped.
+func splitSQLStatements(data string) ([]string, error) {
+	var statements []string
+
+	var current strings.Builder
+
+	var block sqlBlock
+
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+
+		current.Reset()
+
+		block = sqlBlock{}
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		switch {
+		case c == '\'' || c == '"':
+			end, err := findSQLQuoteEnd(data, i)
+			if err != nil {
+				return nil, err
+			}
+
+			current.WriteString(data[i : end+1])
+			i = end
+		case c == '-' && i+1 < len(data) && data[i+1] == '-':
+			end := strings.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+
+			current.WriteByte(' ')
+			i += end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(data[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: line %d: unterminated comment", ErrWrongSQLScript, sqlLine(data, i))
+			}
+
+			current.WriteByte(' ')
+			i += end + 3
+		case c == ';' && block.depth == 0:
+			flush()
+		case isSQLWordByte(c):
+			end := i + 1
+			for end < len(data) && isSQLWordByte(data[end]) {
+				end++
+			}
+
+			block.word(data[i:end])
+			current.WriteString(data[i:end])
+			i = end - 1
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	return statements, nil
+}
+
+// sqlBlock tracks BEGIN ... END body of CREATE TRIGGER statement, CASE ... END expressions inside are nested.
+type sqlBlock struct {
+	words   int
+	trigger bool
+	depth   int
+}
+
+func (b *sqlBlock) word(word string) {
+	b.words++
+	upper := strings.ToUpper(word)
+
+	if b.words == 2 && upper == "TRIGGER" {
+		b.trigger = true
+	}
+
+	if !b.trigger {
+		return
+	}
+
+	switch upper {
+	case "BEGIN", "CASE":
+		b.depth++
+	case "END":
+		if b.depth > 0 {
+			b.depth--
+		}
+	}
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// findSQLQuoteEnd returns position of the closing quote, doubled quotes are escapes.
+func findSQLQuoteEnd(data string, start int) (int, error) {
+	quote := data[start]
+
+	for i := start + 1; i < len(data); i++ {
+		if data[i] != quote {
+			continue
+		}
+
+		if i+1 < len(data) && data[i+1] == quote {
+			i++
+
+			continue
+		}
+
+		return i, nil
+	}
+
+	return 0, fmt.Errorf("%w: line %d: unterminated quoted string", ErrWrongSQLScript, sqlLine(data, start))
+}
+
+func sqlLine(data string, offset int) int {
+	return strings.Count(data[:offset], "\n") + 1
+}
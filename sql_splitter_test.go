@@ -0,0 +1,77 @@
+package tarantool_migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SQLSplitterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatements() {
+	result, err := splitSQLStatements("CREATE TABLE t (id INTEGER PRIMARY KEY, s STRING);\n" +
+		"INSERT INTO t VALUES (1, 'a;b''c');\n" +
+		"-- comment; with separator\n" +
+		"INSERT INTO \"t\" VALUES (2, 'x') /* block; comment */;\n" +
+		";\n" +
+		"SELECT 1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{
+		"CREATE TABLE t (id INTEGER PRIMARY KEY, s STRING)",
+		"INSERT INTO t VALUES (1, 'a;b''c')",
+		"INSERT INTO \"t\" VALUES (2, 'x')",
+		"SELECT 1",
+	}, result)
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatementsTrigger() {
+	result, err := splitSQLStatements("CREATE TABLE t (id INTEGER PRIMARY KEY);\n" +
+		"create trigger t_log after insert on t for each row begin\n" +
+		"  INSERT INTO log VALUES (1);\n" +
+		"  UPDATE counters SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;\n" +
+		"END;\n" +
+		"INSERT INTO t VALUES (1)")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{
+		"CREATE TABLE t (id INTEGER PRIMARY KEY)",
+		"create trigger t_log after insert on t for each row begin\n" +
+			"  INSERT INTO log VALUES (1);\n" +
+			"  UPDATE counters SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;\n" +
+			"END",
+		"INSERT INTO t VALUES (1)",
+	}, result)
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatementsBeginOutsideTrigger() {
+	result, err := splitSQLStatements("SELECT begin_at FROM t; SELECT 1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"SELECT begin_at FROM t", "SELECT 1"}, result)
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatementsOnlyComments() {
+	result, err := splitSQLStatements("-- comment\n/* comment */")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result)
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatementsUnterminatedString() {
+	result, err := splitSQLStatements("SELECT 'foo;")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrWrongSQLScript)
+	assert.Equal(suite.T(), "wrong sql script: line 1: unterminated quoted string", err.Error())
+}
+
+func (suite *SQLSplitterTestSuite) TestSplitSQLStatementsUnterminatedComment() {
+	result, err := splitSQLStatements("SELECT 1;\n/* foo")
+	assert.Nil(suite.T(), result)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "wrong sql script: line 2: unterminated comment", err.Error())
+}
+
+func TestSQLSplitterTestSuite(t *testing.T) {
+	suite.Run(t, new(SQLSplitterTestSuite))
+}
@@ -0,0 +1,35 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"time"
+)
+
+// AppliedMigration is the record of applied migration kept by Store.
+type AppliedMigration struct {
+	// ID of the migration
+	ID string `json:"id"`
+	// ExecutedAt is the time of applying
+	ExecutedAt time.Time `json:"executed_at"`
+}
+
+// Store keeps records of applied migrations. By default, the migrator uses TarantoolStore on the migrated pool,
+// another implementation can be set with WithStore, e.g. to keep records in a separate control cluster.
+type Store interface {
+	// Init prepares the storage, e.g. creates migrations space
+	Init(ctx context.Context) error
+	// List returns applied migrations ordered by ID
+	List(ctx context.Context) ([]AppliedMigration, error)
+	// Get returns applied migration or nil
+	Get(ctx context.Context, id string) (*AppliedMigration, error)
+	// Last returns applied migration with the greatest ID or ErrNoAppliedMigrations
+	Last(ctx context.Context) (*AppliedMigration, error)
+	// Insert records applied migration
+	Insert(ctx context.Context, id string) error
+	// Delete removes the record of rolled back migration
+	Delete(ctx context.Context, id string) error
+	// Lock prevents concurrent runs of the migrator (see Options.Lock), ErrStoreLocked is returned when locked
+	Lock(ctx context.Context) error
+	// Unlock releases the lock taken by Lock
+	Unlock(ctx context.Context) error
+}
@@ -0,0 +1,192 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileStore keeps applied migrations in a JSON file, it is useful for local development.
+// The lock is a sibling file with ".lock" suffix, it must be removed manually after a crash.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates store in the file, the file is created by Init.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Init(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(s.path); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("create store dir: %w", err)
+	}
+
+	return s.write(map[string]AppliedMigration{})
+}
+
+func (s *FileStore) List(_ context.Context) ([]AppliedMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied, err := s.read()
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+
+	return sortedAppliedMigrations(applied), nil
+}
+
+func (s *FileStore) Get(_ context.Context, id string) (*AppliedMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied, err := s.read()
+	if err != nil {
+		return nil, fmt.Errorf("check applied migration: %w", err)
+	}
+
+	record, ok := applied[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+func (s *FileStore) Last(ctx context.Context) (*AppliedMigration, error) {
+	applied, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) == 0 {
+		return nil, ErrNoAppliedMigrations
+	}
+
+	return &applied[len(applied)-1], nil
+}
+
+func (s *FileStore) Insert(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied, err := s.read()
+	if err != nil {
+		return fmt.Errorf("insert migration record: %w", err)
+	}
+
+	if _, ok := applied[id]; ok {
+		return fmt.Errorf("insert migration record: %w: %s", ErrMigrationAlreadyApplied, id)
+	}
+
+	applied[id] = AppliedMigration{ID: id, ExecutedAt: time.Now().UTC()}
+
+	if err = s.write(applied); err != nil {
+		return fmt.Errorf("insert migration record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied, err := s.read()
+	if err != nil {
+		return fmt.Errorf("delete migration record: %w", err)
+	}
+
+	delete(applied, id)
+
+	if err = s.write(applied); err != nil {
+		return fmt.Errorf("delete migration record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) Lock(_ context.Context) error {
+	file, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s.lock exists", ErrStoreLocked, s.path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("lock store: %w", err)
+	}
+
+	_, err = fmt.Fprintf(file, "%d\n", os.Getpid())
+
+	return errors.Join(err, file.Close())
+}
+
+func (s *FileStore) Unlock(_ context.Context) error {
+	if err := os.Remove(s.path + ".lock"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unlock store: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) read() (map[string]AppliedMigration, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]AppliedMigration{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var records []AppliedMigration
+	if err = json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", s.path, err)
+	}
+
+	applied := make(map[string]AppliedMigration, len(records))
+	for _, record := range records {
+		applied[record.ID] = record
+	}
+
+	return applied, nil
+}
+
+// write replaces the file atomically, so an interrupted write doesn't corrupt it.
+func (s *FileStore) write(applied map[string]AppliedMigration) error {
+	data, err := json.MarshalIndent(sortedAppliedMigrations(applied), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err = file.Write(data); err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), s.path)
+}
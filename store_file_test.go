@@ -0,0 +1,103 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FileStoreTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	path     string
+	testable *FileStore
+}
+
+func (suite *FileStoreTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.path = filepath.Join(suite.T().TempDir(), "state", "migrations.json")
+	suite.testable = NewFileStore(suite.path)
+}
+
+func (suite *FileStoreTestSuite) TestInit() {
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+
+	data, err := os.ReadFile(suite.path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "[]", string(data))
+
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"))
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+
+	applied, err := suite.testable.List(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), applied, 1)
+}
+
+func (suite *FileStoreTestSuite) TestInsertListAndDelete() {
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-2"))
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"))
+
+	applied, err := NewFileStore(suite.path).List(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), applied, 2)
+	assert.Equal(suite.T(), "migration-1", applied[0].ID)
+	assert.False(suite.T(), applied[0].ExecutedAt.IsZero())
+
+	record, err := suite.testable.Last(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "migration-2", record.ID)
+
+	assert.NoError(suite.T(), suite.testable.Delete(suite.ctx, "migration-2"))
+
+	record, err = suite.testable.Get(suite.ctx, "migration-2")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), record)
+
+	record, err = suite.testable.Get(suite.ctx, "migration-1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "migration-1", record.ID)
+}
+
+func (suite *FileStoreTestSuite) TestInsertDuplicate() {
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"))
+	assert.ErrorIs(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"), ErrMigrationAlreadyApplied)
+}
+
+func (suite *FileStoreTestSuite) TestLastWithoutFile() {
+	record, err := suite.testable.Last(suite.ctx)
+	assert.Nil(suite.T(), record)
+	assert.ErrorIs(suite.T(), err, ErrNoAppliedMigrations)
+}
+
+func (suite *FileStoreTestSuite) TestListBrokenFile() {
+	assert.NoError(suite.T(), os.MkdirAll(filepath.Dir(suite.path), 0o750))
+	assert.NoError(suite.T(), os.WriteFile(suite.path, []byte("{"), 0o600))
+
+	_, err := suite.testable.List(suite.ctx)
+	assert.Equal(suite.T(), "list applied migrations: decode "+suite.path+": unexpected end of JSON input",
+		err.Error())
+}
+
+func (suite *FileStoreTestSuite) TestLock() {
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Lock(suite.ctx))
+
+	err := NewFileStore(suite.path).Lock(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrStoreLocked)
+	assert.Equal(suite.T(), "migrations store is locked: "+suite.path+".lock exists", err.Error())
+
+	assert.NoError(suite.T(), suite.testable.Unlock(suite.ctx))
+	assert.NoFileExists(suite.T(), suite.path+".lock")
+	assert.NoError(suite.T(), suite.testable.Unlock(suite.ctx))
+}
+
+func TestFileStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(FileStoreTestSuite))
+}
@@ -0,0 +1,115 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps applied migrations in memory, it is useful for tests.
+type MemoryStore struct {
+	mu      sync.Mutex
+	applied map[string]AppliedMigration
+	locked  bool
+}
+
+// NewMemoryStore creates store with the given applied migrations.
+func NewMemoryStore(applied ...AppliedMigration) *MemoryStore {
+	s := &MemoryStore{applied: make(map[string]AppliedMigration, len(applied))}
+	for _, record := range applied {
+		s.applied[record.ID] = record
+	}
+
+	return s
+}
+
+func (s *MemoryStore) Init(_ context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) List(_ context.Context) ([]AppliedMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedAppliedMigrations(s.applied), nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*AppliedMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.applied[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+func (s *MemoryStore) Last(ctx context.Context) (*AppliedMigration, error) {
+	applied, _ := s.List(ctx)
+	if len(applied) == 0 {
+		return nil, ErrNoAppliedMigrations
+	}
+
+	return &applied[len(applied)-1], nil
+}
+
+func (s *MemoryStore) Insert(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.applied[id]; ok {
+		return fmt.Errorf("insert migration record: %w: %s", ErrMigrationAlreadyApplied, id)
+	}
+
+	s.applied[id] = AppliedMigration{ID: id, ExecutedAt: time.Now().UTC()}
+
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.applied, id)
+
+	return nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.locked {
+		return ErrStoreLocked
+	}
+
+	s.locked = true
+
+	return nil
+}
+
+func (s *MemoryStore) Unlock(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locked = false
+
+	return nil
+}
+
+func sortedAppliedMigrations(applied map[string]AppliedMigration) []AppliedMigration {
+	records := make([]AppliedMigration, 0, len(applied))
+	for _, record := range applied {
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	return records
+}
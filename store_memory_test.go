@@ -0,0 +1,74 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryStoreTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	testable *MemoryStore
+}
+
+func (suite *MemoryStoreTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.testable = NewMemoryStore(AppliedMigration{ID: "migration-2", ExecutedAt: time.Unix(2, 0)})
+}
+
+func (suite *MemoryStoreTestSuite) TestInsertAndList() {
+	assert.NoError(suite.T(), suite.testable.Init(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"))
+
+	applied, err := suite.testable.List(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), applied, 2)
+	assert.Equal(suite.T(), "migration-1", applied[0].ID)
+	assert.Equal(suite.T(), "migration-2", applied[1].ID)
+}
+
+func (suite *MemoryStoreTestSuite) TestInsertDuplicate() {
+	err := suite.testable.Insert(suite.ctx, "migration-2")
+	assert.ErrorIs(suite.T(), err, ErrMigrationAlreadyApplied)
+	assert.Equal(suite.T(), "insert migration record: migration is already applied: migration-2", err.Error())
+}
+
+func (suite *MemoryStoreTestSuite) TestGet() {
+	record, err := suite.testable.Get(suite.ctx, "migration-2")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &AppliedMigration{ID: "migration-2", ExecutedAt: time.Unix(2, 0)}, record)
+
+	record, err = suite.testable.Get(suite.ctx, "migration-1")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), record)
+}
+
+func (suite *MemoryStoreTestSuite) TestLastAndDelete() {
+	assert.NoError(suite.T(), suite.testable.Insert(suite.ctx, "migration-1"))
+
+	record, err := suite.testable.Last(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "migration-2", record.ID)
+
+	assert.NoError(suite.T(), suite.testable.Delete(suite.ctx, "migration-2"))
+	assert.NoError(suite.T(), suite.testable.Delete(suite.ctx, "migration-1"))
+
+	record, err = suite.testable.Last(suite.ctx)
+	assert.Nil(suite.T(), record)
+	assert.ErrorIs(suite.T(), err, ErrNoAppliedMigrations)
+}
+
+func (suite *MemoryStoreTestSuite) TestLock() {
+	assert.NoError(suite.T(), suite.testable.Lock(suite.ctx))
+	assert.ErrorIs(suite.T(), suite.testable.Lock(suite.ctx), ErrStoreLocked)
+	assert.NoError(suite.T(), suite.testable.Unlock(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Lock(suite.ctx))
+}
+
+func TestMemoryStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryStoreTestSuite))
+}
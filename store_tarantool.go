@@ -0,0 +1,204 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const storeLockKey = "tarantool_migrator.lock"
+
+// storeLockState is the result of the lock lua script.
+type storeLockState struct {
+	_msgpack struct{} `msgpack:",as_array"` //nolint:unused
+	// Locked is true when the lock is taken by this owner
+	Locked bool
+	// Owner is the current owner of the lock
+	Owner string
+}
+
+// TarantoolStore keeps applied migrations in Options.MigrationsSpace and the lock in Options.StateSpace.
+type TarantoolStore struct {
+	tt    pool.Pooler
+	opts  *Options
+	owner string
+}
+
+// NewTarantoolStore creates store on the pool, which can differ from the migrated one.
+func NewTarantoolStore(tt pool.Pooler, opts Options) *TarantoolStore {
+	return newTarantoolStore(tt, &opts)
+}
+
+func newTarantoolStore(tt pool.Pooler, opts *Options) *TarantoolStore {
+	hostname, _ := os.Hostname()
+
+	return &TarantoolStore{
+		tt:    tt,
+		opts:  opts,
+		owner: fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), time.Now().UnixNano()),
+	}
+}
+
+func (s *TarantoolStore) Init(ctx context.Context) error {
+	return s.createSpace(ctx, createMigrationsSpacePath)
+}
+
+func (s *TarantoolStore) createSpace(ctx context.Context, path string) error {
+	data, err := LuaFs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{s.opts.MigrationsSpace})
+
+	_, err = s.tt.Do(req, s.opts.WriteMode).Get()
+	if err != nil {
+		return fmt.Errorf("exec create migrations space: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TarantoolStore) List(ctx context.Context) ([]AppliedMigration, error) {
+	var tuples [][]migrationTuple
+
+	data, err := LuaFs.ReadFile(listAppliedMigrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{s.opts.MigrationsSpace})
+
+	err = s.tt.Do(req, s.opts.ReadMode).GetTyped(&tuples)
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+
+	if len(tuples) == 0 {
+		return nil, nil
+	}
+
+	applied := make([]AppliedMigration, 0, len(tuples[0]))
+	for _, tuple := range tuples[0] {
+		applied = append(applied, tuple.applied())
+	}
+
+	return applied, nil
+}
+
+func (s *TarantoolStore) Get(ctx context.Context, id string) (*AppliedMigration, error) {
+	var tuples []migrationTuple
+
+	req := tarantool.NewSelectRequest(s.opts.MigrationsSpace).Context(ctx).Key([]any{id})
+
+	err := s.tt.Do(req, s.opts.ReadMode).GetTyped(&tuples)
+	if err != nil {
+		return nil, fmt.Errorf("check applied migration: %w", err)
+	}
+
+	if len(tuples) == 0 {
+		return nil, nil
+	}
+
+	applied := tuples[0].applied()
+
+	return &applied, nil
+}
+
+func (s *TarantoolStore) Last(ctx context.Context) (*AppliedMigration, error) {
+	var tuples []migrationTuple
+
+	data, err := LuaFs.ReadFile(findLastMigrationPath)
+	if err != nil {
+		return nil, fmt.Errorf("read lua script: %w", err)
+	}
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{s.opts.MigrationsSpace})
+
+	err = s.tt.Do(req, s.opts.ReadMode).GetTyped(&tuples)
+	if err != nil {
+		return nil, fmt.Errorf("find last applied migration: %w", err)
+	}
+
+	if len(tuples) == 0 {
+		return nil, ErrNoAppliedMigrations
+	}
+
+	applied := tuples[0].applied()
+
+	return &applied, nil
+}
+
+func (s *TarantoolStore) Insert(ctx context.Context, id string) error {
+	tuple := newMigrationTuple(id)
+
+	_, err := s.tt.Do(tarantool.NewInsertRequest(s.opts.MigrationsSpace).Context(ctx).Tuple(tuple.ToSlice()),
+		s.opts.WriteMode,
+	).Get()
+
+	var serverErr tarantool.ServerError
+	if errors.As(err, &serverErr) && serverErr.Code == iproto.ER_TUPLE_FOUND {
+		return fmt.Errorf("insert migration record: %w: %s", ErrMigrationAlreadyApplied, id)
+	}
+
+	if err != nil {
+		return fmt.Errorf("insert migration record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TarantoolStore) Delete(ctx context.Context, id string) error {
+	req := tarantool.NewDeleteRequest(s.opts.MigrationsSpace).Context(ctx).Key([]any{id})
+
+	_, err := s.tt.Do(req, s.opts.WriteMode).Get()
+	if err != nil {
+		return fmt.Errorf("delete migration record: %w", err)
+	}
+
+	return nil
+}
+
+// Lock stores the owner of the lock in Options.StateSpace. The lock of crashed process
+// must be released manually: box.space._migrator_state:delete('tarantool_migrator.lock').
+func (s *TarantoolStore) Lock(ctx context.Context) error {
+	data, err := LuaFs.ReadFile(lockStorePath)
+	if err != nil {
+		return fmt.Errorf("read lua script: %w", err)
+	}
+
+	var states []storeLockState
+
+	req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{s.opts.StateSpace, storeLockKey, s.owner})
+
+	err = s.tt.Do(req, s.opts.WriteMode).GetTyped(&states)
+	if err != nil {
+		return fmt.Errorf("lock store: %w", err)
+	}
+
+	if len(states) == 0 || !states[0].Locked {
+		owner := ""
+		if len(states) > 0 {
+			owner = states[0].Owner
+		}
+
+		return fmt.Errorf("%w: by %s", ErrStoreLocked, owner)
+	}
+
+	return nil
+}
+
+func (s *TarantoolStore) Unlock(ctx context.Context) error {
+	if err := evalLuaScript(ctx, s.tt, *s.opts, unlockStorePath, s.opts.StateSpace, storeLockKey,
+		s.owner); err != nil {
+		return fmt.Errorf("unlock store: %w", err)
+	}
+
+	return nil
+}
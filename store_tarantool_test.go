@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strings"
 	"testing"
 
 	"github.com/kachit/tarantool-migrator/mocks"
@@ -16,37 +15,34 @@ import (
 	"github.com/tarantool/go-tarantool/v3/test_helpers"
 )
 
-type ExecutorBaseTestSuite struct {
+type TarantoolStoreTestSuite struct {
 	suite.Suite
 	ctx      context.Context
 	mock     *mocks.PoolerMock
-	testable *executorBase
+	testable *TarantoolStore
 }
 
-func (suite *ExecutorBaseTestSuite) SetupTest() {
+func (suite *TarantoolStoreTestSuite) SetupTest() {
 	suite.mock = &mocks.PoolerMock{}
 	suite.ctx = context.Background()
-	suite.testable = &executorBase{
-		tt: suite.mock,
-		opts: &Options{
-			MigrationsSpace: "migrations",
-			ReadMode:        pool.ModeAny,
-			WriteMode:       pool.ModeRW,
-		},
-	}
+	suite.testable = NewTarantoolStore(suite.mock, Options{
+		MigrationsSpace: "migrations",
+		StateSpace:      "state",
+		ReadMode:        pool.ModeAny,
+		WriteMode:       pool.ModeRW,
+	})
 }
 
-func (suite *ExecutorBaseTestSuite) TestCreateMigrationsSpaceIfNotExistsSuccess() {
+func (suite *TarantoolStoreTestSuite) TestInitSuccess() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.createMigrationsSpaceIfNotExists(suite.ctx, createMigrationsSpacePath)
+	err := suite.testable.Init(suite.ctx)
 
 	data, _ := LuaFs.ReadFile("lua/migrations/create_migrations_space.up.lua")
 	migrationSpaceRequest := string(data)
-	migrationSpaceRequest = strings.ReplaceAll(migrationSpaceRequest, "_migrations_space_", "migrations")
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
@@ -60,28 +56,27 @@ func (suite *ExecutorBaseTestSuite) TestCreateMigrationsSpaceIfNotExistsSuccess(
 	exprField := reqRef.FieldByName("expr")
 	assert.Equal(suite.T(), migrationSpaceRequest, exprField.String())
 	argsField := reqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestCreateMigrationsSpaceIfNotExistsWrongMigrationsPathError() {
-	err := suite.testable.createMigrationsSpaceIfNotExists(suite.ctx, "lua/migrations/create_migrations_space.up.dua")
+func (suite *TarantoolStoreTestSuite) TestCreateSpaceWrongMigrationsPathError() {
+	err := suite.testable.createSpace(suite.ctx, "lua/migrations/create_migrations_space.up.dua")
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "read lua script: open lua/migrations/create_migrations_space.up.dua: file does not exist", err.Error())
 	assert.Len(suite.T(), calls, 0)
 }
 
-func (suite *ExecutorBaseTestSuite) TestCreateMigrationsSpaceIfNotExistsError() {
+func (suite *TarantoolStoreTestSuite) TestInitError() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.createMigrationsSpaceIfNotExists(suite.ctx, createMigrationsSpacePath)
+	err := suite.testable.Init(suite.ctx)
 
 	data, _ := LuaFs.ReadFile("lua/migrations/create_migrations_space.up.lua")
 	migrationSpaceRequest := string(data)
-	migrationSpaceRequest = strings.ReplaceAll(migrationSpaceRequest, "_migrations_space_", "migrations")
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
@@ -96,20 +91,20 @@ func (suite *ExecutorBaseTestSuite) TestCreateMigrationsSpaceIfNotExistsError()
 	exprField := reqRef.FieldByName("expr")
 	assert.Equal(suite.T(), migrationSpaceRequest, exprField.String())
 	argsField := reqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationFound() {
+func (suite *TarantoolStoreTestSuite) TestGetFound() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw(newMigrationTupleStubResponseBody())
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	found, err := suite.testable.hasAppliedMigration(suite.ctx, "qwerty")
+	found, err := suite.testable.Get(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
-	assert.True(suite.T(), found)
+	assert.NotNil(suite.T(), found)
 	assert.Len(suite.T(), calls, 1)
 	assert.Equal(suite.T(), pool.ModeAny, calls[0].Mode)
 
@@ -125,17 +120,17 @@ func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationFound() {
 	assert.True(suite.T(), indexField.IsNil())
 }
 
-func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationNotFound() {
+func (suite *TarantoolStoreTestSuite) TestGetNotFound() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	found, err := suite.testable.hasAppliedMigration(suite.ctx, "qwerty")
+	found, err := suite.testable.Get(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
-	assert.False(suite.T(), found)
+	assert.Nil(suite.T(), found)
 	assert.Len(suite.T(), calls, 1)
 	assert.Equal(suite.T(), pool.ModeAny, calls[0].Mode)
 
@@ -151,18 +146,18 @@ func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationNotFound() {
 	assert.True(suite.T(), indexField.IsNil())
 }
 
-func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationError() {
+func (suite *TarantoolStoreTestSuite) TestGetError() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	found, err := suite.testable.hasAppliedMigration(suite.ctx, "qwerty")
+	found, err := suite.testable.Get(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "check applied migration: tarantool error", err.Error())
-	assert.False(suite.T(), found)
+	assert.Nil(suite.T(), found)
 	assert.Len(suite.T(), calls, 1)
 	assert.Equal(suite.T(), pool.ModeAny, calls[0].Mode)
 
@@ -178,13 +173,13 @@ func (suite *ExecutorBaseTestSuite) TestHasAppliedMigrationError() {
 	assert.True(suite.T(), indexField.IsNil())
 }
 
-func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationFound() {
+func (suite *TarantoolStoreTestSuite) TestLastFound() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw(newMigrationTupleStubResponseBody())
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	result, err := suite.testable.findLastAppliedMigration(suite.ctx)
+	result, err := suite.testable.Last(suite.ctx)
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
@@ -197,18 +192,19 @@ func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationFound() {
 	assert.IsType(suite.T(), tarantool.EvalRequest{}, req)
 	assert.Equal(suite.T(), iproto.IPROTO_EVAL, req.Type())
 	exprField := reqRef.FieldByName("expr")
-	assert.Equal(suite.T(), "return box.space.migrations.index.id:max()", exprField.String())
+	assert.Equal(suite.T(), "local space_name = ...\n\nreturn box.space[space_name].index.id:max()\n",
+		exprField.String())
 	argsField := reqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationNotFound() {
+func (suite *TarantoolStoreTestSuite) TestLastNotFound() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	result, err := suite.testable.findLastAppliedMigration(suite.ctx)
+	result, err := suite.testable.Last(suite.ctx)
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
@@ -222,18 +218,19 @@ func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationNotFound() {
 	assert.IsType(suite.T(), tarantool.EvalRequest{}, req)
 	assert.Equal(suite.T(), iproto.IPROTO_EVAL, req.Type())
 	exprField := reqRef.FieldByName("expr")
-	assert.Equal(suite.T(), "return box.space.migrations.index.id:max()", exprField.String())
+	assert.Equal(suite.T(), "local space_name = ...\n\nreturn box.space[space_name].index.id:max()\n",
+		exprField.String())
 	argsField := reqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationError() {
+func (suite *TarantoolStoreTestSuite) TestLastError() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	result, err := suite.testable.findLastAppliedMigration(suite.ctx)
+	result, err := suite.testable.Last(suite.ctx)
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
@@ -247,18 +244,53 @@ func (suite *ExecutorBaseTestSuite) TestFindLastAppliedMigrationError() {
 	assert.IsType(suite.T(), tarantool.EvalRequest{}, req)
 	assert.Equal(suite.T(), iproto.IPROTO_EVAL, req.Type())
 	exprField := reqRef.FieldByName("expr")
-	assert.Equal(suite.T(), "return box.space.migrations.index.id:max()", exprField.String())
+	assert.Equal(suite.T(), "local space_name = ...\n\nreturn box.space[space_name].index.id:max()\n",
+		exprField.String())
 	argsField := reqRef.FieldByName("args")
-	assert.Equal(suite.T(), "[]", fmt.Sprintf("%v", argsField))
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestInsertMigrationSuccess() {
+func (suite *TarantoolStoreTestSuite) TestListFound() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][][]interface{}{newMigrationTupleStubResponseBody()})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+	result, err := suite.testable.List(suite.ctx)
+
+	calls := suite.mock.DoCalls()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Len(suite.T(), calls, 1)
+	assert.Equal(suite.T(), pool.ModeAny, calls[0].Mode)
+
+	reqRef := reflect.ValueOf(calls[0].Req)
+	req := reqRef.Interface().(tarantool.EvalRequest)
+	assert.Equal(suite.T(), iproto.IPROTO_EVAL, req.Type())
+	argsField := reqRef.FieldByName("args")
+	assert.Equal(suite.T(), "[migrations]", fmt.Sprintf("%v", argsField))
+}
+
+func (suite *TarantoolStoreTestSuite) TestListError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+	result, err := suite.testable.List(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "list applied migrations: tarantool error", err.Error())
+	assert.Empty(suite.T(), result)
+}
+
+func (suite *TarantoolStoreTestSuite) TestInsertSuccess() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw(newMigrationTupleStubResponseBody())
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.insertMigration(suite.ctx, suite.mock, "qwerty")
+	err := suite.testable.Insert(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
@@ -273,13 +305,13 @@ func (suite *ExecutorBaseTestSuite) TestInsertMigrationSuccess() {
 	assert.Equal(suite.T(), "migrations", fmt.Sprintf("%v", spaceField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestInsertMigrationError() {
+func (suite *TarantoolStoreTestSuite) TestInsertError() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.insertMigration(suite.ctx, suite.mock, "qwerty")
+	err := suite.testable.Insert(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
@@ -295,13 +327,25 @@ func (suite *ExecutorBaseTestSuite) TestInsertMigrationError() {
 	assert.Equal(suite.T(), "migrations", fmt.Sprintf("%v", spaceField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestDeleteMigrationSuccess() {
+func (suite *TarantoolStoreTestSuite) TestInsertDuplicate() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseError(tarantool.ServerError{Code: iproto.ER_TUPLE_FOUND, Msg: "Duplicate key exists"})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+	err := suite.testable.Insert(suite.ctx, "qwerty")
+
+	assert.ErrorIs(suite.T(), err, ErrMigrationAlreadyApplied)
+	assert.Equal(suite.T(), "insert migration record: migration is already applied: qwerty", err.Error())
+}
+
+func (suite *TarantoolStoreTestSuite) TestDeleteSuccess() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseRaw([][]interface{}{})
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.deleteMigration(suite.ctx, suite.mock, "qwerty")
+	err := suite.testable.Delete(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.NoError(suite.T(), err)
@@ -318,13 +362,13 @@ func (suite *ExecutorBaseTestSuite) TestDeleteMigrationSuccess() {
 	assert.Equal(suite.T(), "[qwerty]", fmt.Sprintf("%v", keyField))
 }
 
-func (suite *ExecutorBaseTestSuite) TestDeleteMigrationError() {
+func (suite *TarantoolStoreTestSuite) TestDeleteError() {
 	mockDoer := test_helpers.NewMockDoer(suite.T())
 	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
 	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
 		return mockDoer.Do(req)
 	}
-	err := suite.testable.deleteMigration(suite.ctx, suite.mock, "qwerty")
+	err := suite.testable.Delete(suite.ctx, "qwerty")
 
 	calls := suite.mock.DoCalls()
 	assert.Error(suite.T(), err)
@@ -342,6 +386,51 @@ func (suite *ExecutorBaseTestSuite) TestDeleteMigrationError() {
 	assert.Equal(suite.T(), "[qwerty]", fmt.Sprintf("%v", keyField))
 }
 
-func TestExecutorBaseTestSuite(t *testing.T) {
-	suite.Run(t, new(ExecutorBaseTestSuite))
+func (suite *TarantoolStoreTestSuite) TestLock() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{{true, suite.testable.owner}})
+	mockDoer.AddResponseRaw([]interface{}{true})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	assert.NoError(suite.T(), suite.testable.Lock(suite.ctx))
+	assert.NoError(suite.T(), suite.testable.Unlock(suite.ctx))
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 2)
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+
+	for _, call := range calls {
+		rec, err := describeRequest(call.Req, call.Mode)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []any{"state", storeLockKey, suite.testable.owner}, rec.Args)
+	}
+}
+
+func (suite *TarantoolStoreTestSuite) TestLockHeldByAnotherOwner() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseRaw([][]interface{}{{false, "host:1"}})
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	err := suite.testable.Lock(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrStoreLocked)
+	assert.Equal(suite.T(), "migrations store is locked: by host:1", err.Error())
+}
+
+func (suite *TarantoolStoreTestSuite) TestLockError() {
+	mockDoer := test_helpers.NewMockDoer(suite.T())
+	mockDoer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return mockDoer.Do(req)
+	}
+
+	err := suite.testable.Lock(suite.ctx)
+	assert.Equal(suite.T(), "lock store: tarantool error", err.Error())
+}
+
+func TestTarantoolStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(TarantoolStoreTestSuite))
 }
@@ -0,0 +1,124 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tarantoolVersion is the numeric part of box.info.version, e.g. "2.11.1-0-g96877bd".
+type tarantoolVersion [3]int
+
+func (v tarantoolVersion) less(other tarantoolVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+
+	return false
+}
+
+func (v tarantoolVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// parseTarantoolVersion parses "major[.minor[.patch]]" with optional "-suffix".
+func parseTarantoolVersion(value string) (tarantoolVersion, error) {
+	var version tarantoolVersion
+
+	numbers, _, _ := strings.Cut(strings.TrimSpace(value), "-")
+
+	parts := strings.Split(numbers, ".")
+	if len(parts) > len(version) {
+		return version, fmt.Errorf("%w: %q", ErrWrongTarantoolVersion, value)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version, fmt.Errorf("%w: %q", ErrWrongTarantoolVersion, value)
+		}
+
+		version[i] = n
+	}
+
+	return version, nil
+}
+
+// VersionPolicy defines what the migrator does with migrations not supporting the server version.
+type VersionPolicy int
+
+const (
+	// VersionPolicyRefuse stops the run with ErrTarantoolVersionMismatch
+	VersionPolicyRefuse VersionPolicy = iota
+	// VersionPolicySkip skips the pending migration with a warning, it stays pending.
+	// Rollback of an applied migration is refused with ErrTarantoolVersionMismatch under any policy.
+	VersionPolicySkip
+)
+
+// tarantoolVersion returns the version of the server the migration is written to, fetched once per run.
+func (m *Migrator) tarantoolVersion(ctx context.Context, run *migratorRun,
+	migration *Migration) (tarantoolVersion, error) {
+	mode := migration.options(*m.opts).WriteMode
+	if version, ok := run.serverVersions[mode]; ok {
+		return version, nil
+	}
+
+	value, err := m.ex.serverVersion(ctx, mode)
+	if err != nil {
+		return tarantoolVersion{}, err
+	}
+
+	version, err := parseTarantoolVersion(value)
+	if err != nil {
+		return version, err
+	}
+
+	run.setServerVersion(mode, version)
+
+	return version, nil
+}
+
+// checkTarantoolVersion returns ErrTarantoolVersionMismatch when the server doesn't satisfy the migration bounds.
+func (m *Migrator) checkTarantoolVersion(ctx context.Context, run *migratorRun, migration *Migration) error {
+	if !migration.hasTarantoolVersionBounds() {
+		return nil
+	}
+
+	server, err := m.tarantoolVersion(ctx, run, migration)
+	if err != nil {
+		return err
+	}
+
+	return migration.checkTarantoolVersion(server)
+}
+
+// skipByTarantoolVersion returns true when the pending migration must be skipped according to VersionPolicy.
+// Migrations depending on a skipped one are skipped too, they would run against the missing schema.
+func (m *Migrator) skipByTarantoolVersion(ctx context.Context, run *migratorRun, migration *Migration) (bool, error) {
+	for _, dep := range migration.Depends {
+		if run.skipped[dep] {
+			m.logger.WarnContext(ctx, "migration is skipped", "id", migration.ID, "depends", dep)
+			run.skip(migration.ID)
+
+			return true, nil
+		}
+	}
+
+	err := m.checkTarantoolVersion(ctx, run, migration)
+	if err == nil {
+		return false, nil
+	}
+
+	if m.opts.VersionPolicy == VersionPolicySkip && errors.Is(err, ErrTarantoolVersionMismatch) {
+		m.logger.WarnContext(ctx, "migration is skipped", "id", migration.ID, "error", err)
+		run.skip(migration.ID)
+
+		return true, nil
+	}
+
+	return false, err
+}
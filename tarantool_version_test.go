@@ -0,0 +1,207 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type TarantoolVersionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TarantoolVersionTestSuite) TestParseTarantoolVersion() {
+	version, err := parseTarantoolVersion("2.11.1-0-g96877bd")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), tarantoolVersion{2, 11, 1}, version)
+	assert.Equal(suite.T(), "2.11.1", version.String())
+
+	version, err = parseTarantoolVersion("3")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), tarantoolVersion{3, 0, 0}, version)
+}
+
+func (suite *TarantoolVersionTestSuite) TestParseTarantoolVersionWrong() {
+	for _, value := range []string{"", "2.x", "1.2.3.4", "-1"} {
+		_, err := parseTarantoolVersion(value)
+		assert.ErrorIs(suite.T(), err, ErrWrongTarantoolVersion, value)
+	}
+}
+
+func (suite *TarantoolVersionTestSuite) TestLess() {
+	assert.True(suite.T(), tarantoolVersion{2, 10, 0}.less(tarantoolVersion{2, 11, 0}))
+	assert.True(suite.T(), tarantoolVersion{2, 11, 0}.less(tarantoolVersion{3, 0, 0}))
+	assert.False(suite.T(), tarantoolVersion{2, 11, 1}.less(tarantoolVersion{2, 11, 1}))
+	assert.False(suite.T(), tarantoolVersion{3, 0, 0}.less(tarantoolVersion{2, 11, 5}))
+}
+
+func (suite *TarantoolVersionTestSuite) newMigrator(doer test_helpers.MockDoer, mock *mocks.PoolerMock,
+	options ...func(*Migrator)) *Migrator {
+	mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return doer.Do(req)
+	}
+
+	return NewMigrator(mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "3.0"},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info"), MaxTarantoolVersion: "3.0"},
+	}, append([]func(*Migrator){WithLogger(SilentLogger), WithOptions(&Options{
+		MigrationsSpace: "migrations",
+		ReadMode:        pool.ModeAny,
+		WriteMode:       pool.ModeRW,
+	})}, options...)...)
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateRefusesMigration() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([]string{"2.11.1-0-g96877bd"})
+
+	err := suite.newMigrator(doer, mock).Migrate(context.Background())
+	assert.ErrorIs(suite.T(), err, ErrTarantoolVersionMismatch)
+	assert.Equal(suite.T(), `migration "migration-1" error: tarantool version does not satisfy migration: `+
+		`2.11.1 < 3.0.0`, err.Error())
+	assert.Len(suite.T(), mock.DoCalls(), 3)
+	assert.Equal(suite.T(), pool.ModeRW, mock.DoCalls()[2].Mode)
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateFetchesVersionInMigrationWriteMode() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([]string{"3.1.0"})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([]string{"3.1.0"})
+	doer.AddResponseRaw([][]interface{}{})
+
+	writeMode := pool.ModePreferRW
+	testable := suite.newMigrator(doer, mock, WithStore(NewMemoryStore()))
+	testable.migrations = MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "3.0"},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "3.0",
+			WriteMode: &writeMode},
+	}
+
+	err := testable.Migrate(context.Background())
+	assert.NoError(suite.T(), err)
+
+	calls := mock.DoCalls()
+	assert.Len(suite.T(), calls, 4)
+	assert.Equal(suite.T(), pool.ModeRW, calls[0].Mode)
+	assert.Equal(suite.T(), pool.ModePreferRW, calls[2].Mode)
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateSkipsMigration() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([]string{"2.11.1-0-g96877bd"})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+
+	testable := suite.newMigrator(doer, mock)
+	testable.opts.VersionPolicy = VersionPolicySkip
+	err := testable.Migrate(context.Background())
+	assert.NoError(suite.T(), err)
+
+	calls := mock.DoCalls()
+	assert.Len(suite.T(), calls, 6)
+
+	rec, _ := describeRequest(calls[5].Req, calls[5].Mode)
+	assert.Equal(suite.T(), "IPROTO_INSERT", rec.Type)
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateServerVersionError() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseRaw([][]interface{}{})
+	doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.newMigrator(doer, mock).Migrate(context.Background())
+	assert.Equal(suite.T(), `migration "migration-1" error: get server version: tarantool error`, err.Error())
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateIgnoresAppliedMigrations() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	mock.ConnectedNowFunc = func(mode pool.Mode) (bool, error) {
+		return true, nil
+	}
+	doer.AddResponseRaw([]interface{}{[]string{}, "3.1.0"})
+	doer.AddResponseRaw([][]interface{}{})
+
+	testable := suite.newMigrator(doer, mock, WithStore(NewMemoryStore(AppliedMigration{ID: "old"})))
+	testable.migrations = MigrationsCollection{
+		{ID: "old", Migrate: NewGenericMigrateFunction("box.info"), MaxTarantoolVersion: "2.11"},
+		{ID: "new", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "3.0"},
+	}
+	testable.opts.Preflight = true
+
+	result, err := testable.MigrateWithResult(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ResultTotals{Total: 2, Applied: 1, AlreadyApplied: 1}, result.Totals)
+	assert.Len(suite.T(), mock.DoCalls(), 2)
+}
+
+func (suite *TarantoolVersionTestSuite) TestMigrateSkipsDependents() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([]string{"2.11.1-0-g96877bd"})
+
+	testable := suite.newMigrator(doer, mock, WithStore(NewMemoryStore()))
+	testable.migrations = MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), MinTarantoolVersion: "3.0"},
+		{ID: "migration-2", Migrate: NewGenericMigrateFunction("box.info"), Depends: []string{"migration-1"}},
+		{ID: "migration-3", Migrate: NewGenericMigrateFunction("box.info"), Depends: []string{"migration-2"}},
+	}
+	testable.opts.VersionPolicy = VersionPolicySkip
+
+	result, err := testable.MigrateWithResult(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ResultTotals{Total: 3, Skipped: 3}, result.Totals)
+	assert.Len(suite.T(), mock.DoCalls(), 1)
+}
+
+func (suite *TarantoolVersionTestSuite) TestRollbackRefusesMigrationWithSkipPolicy() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([]string{"2.11.1-0-g96877bd"})
+
+	testable := suite.newMigrator(doer, mock, WithStore(NewMemoryStore(AppliedMigration{ID: "migration-1"})))
+	testable.migrations[0].Rollback = NewGenericMigrateFunction("box.info")
+	testable.opts.VersionPolicy = VersionPolicySkip
+
+	result, err := testable.RollbackLastWithResult(context.Background())
+	assert.ErrorIs(suite.T(), err, ErrTarantoolVersionMismatch)
+	assert.Equal(suite.T(), ResultTotals{Total: 1, Failed: 1}, result.Totals)
+	assert.Len(suite.T(), mock.DoCalls(), 1)
+}
+
+func (suite *TarantoolVersionTestSuite) TestRollbackChecksVersion() {
+	doer := test_helpers.NewMockDoer(suite.T())
+	mock := &mocks.PoolerMock{}
+	doer.AddResponseRaw([]string{"3.1.0"})
+	doer.AddResponseRaw([][]interface{}{})
+
+	testable := suite.newMigrator(doer, mock, WithStore(NewMemoryStore(AppliedMigration{ID: "migration-1"})))
+	testable.migrations[0].Rollback = NewGenericMigrateFunction("box.info")
+
+	result, err := testable.RollbackLastWithResult(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ResultTotals{Total: 1, RolledBack: 1}, result.Totals)
+	assert.Len(suite.T(), mock.DoCalls(), 2)
+}
+
+func TestTarantoolVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(TarantoolVersionTestSuite))
+}
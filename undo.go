@@ -0,0 +1,86 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+)
+
+const DefaultUndoSpace = "_migrator_undo"
+
+// NewUndoRollbackFunction creates rollback function which restores tuples recorded into the undo journal
+// while the migration was running: old images are replaced back and inserted tuples are deleted.
+// Restored entries are removed from the journal, so interrupted rollback can be repeated.
+// The rollback is refused with ErrUndoJournalNotFound when the journal was not recorded or is confirmed.
+func NewUndoRollbackFunction() RollbackFunc {
+	return func(ctx context.Context, tt pool.Pooler, opts Options) error {
+		args := newMigrationArgs(ctx, opts)
+		if args.ID == "" {
+			return fmt.Errorf("undo: %w", ErrMissingID)
+		}
+
+		data, err := LuaFs.ReadFile(undoApplyPath)
+		if err != nil {
+			return fmt.Errorf("read lua script: %w", err)
+		}
+
+		var restored []int
+
+		req := tarantool.NewEvalRequest(string(data)).Context(ctx).Args([]any{opts.UndoSpace, args.ID})
+
+		err = tt.Do(req, opts.WriteMode).GetTyped(&restored)
+		if err != nil {
+			return fmt.Errorf("undo: %w", err)
+		}
+
+		if len(restored) == 0 || restored[0] < 0 {
+			return fmt.Errorf("undo: %w", ErrUndoJournalNotFound)
+		}
+
+		LoggerFromContext(ctx).InfoContext(ctx, "undo journal restored", "tuples", restored[0])
+
+		return nil
+	}
+}
+
+// Confirm deletes undo journals of migrations, they can't be rolled back with the journal anymore.
+func (m *Migrator) Confirm(ctx context.Context, ids ...string) error {
+	for _, id := range ids {
+		if err := m.ex.clearUndo(ctx, id); err != nil {
+			return fmt.Errorf(`migration "%s" error: %w`, id, err)
+		}
+
+		m.logger.InfoContext(ctx, "migration confirmed", "id", id)
+	}
+
+	return nil
+}
+
+// withUndoJournal records changes of Migration.UndoSpaces with on_replace triggers while fn is running.
+// Only writes of the migrator connection session are recorded: application writes made meanwhile are neither
+// journaled nor protected, the rollback overwrites them when they touch the same tuples. Writes of fibers
+// started by the migration (fiber.new, background jobs) run in other sessions and are not recorded either.
+func (e *executorBase) withUndoJournal(ctx context.Context, migration *Migration, fn func() error) (err error) {
+	if len(migration.UndoSpaces) == 0 {
+		return fn()
+	}
+
+	opts := migration.options(*e.opts)
+
+	err = evalLuaScript(ctx, e.tt, opts, undoStartPath, e.opts.UndoSpace, migration.ID, migration.UndoSpaces)
+	if err != nil {
+		return fmt.Errorf("start undo journal: %w", err)
+	}
+
+	defer func() {
+		if stopErr := evalLuaScript(context.WithoutCancel(ctx), e.tt, opts, undoStopPath,
+			migration.ID); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("stop undo journal: %w", stopErr))
+		}
+	}()
+
+	return fn()
+}
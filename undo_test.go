@@ -0,0 +1,168 @@
+package tarantool_migrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kachit/tarantool-migrator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/tarantool/go-tarantool/v3"
+	"github.com/tarantool/go-tarantool/v3/pool"
+	"github.com/tarantool/go-tarantool/v3/test_helpers"
+)
+
+type UndoTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	mock     *mocks.PoolerMock
+	doer     test_helpers.MockDoer
+	testable *Migrator
+}
+
+func (suite *UndoTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.doer = test_helpers.NewMockDoer(suite.T())
+	suite.mock = &mocks.PoolerMock{}
+	suite.mock.DoFunc = func(req tarantool.Request, mode pool.Mode) tarantool.Future {
+		return suite.doer.Do(req)
+	}
+
+	opts := DefaultOptions
+	suite.testable = NewMigrator(suite.mock, MigrationsCollection{
+		{ID: "migration-1", Migrate: NewGenericMigrateFunction("box.info"), UndoSpaces: []string{"users"}},
+	}, WithLogger(SilentLogger), WithOptions(&opts))
+}
+
+func (suite *UndoTestSuite) args(call int) any {
+	calls := suite.mock.DoCalls()
+	rec, err := describeRequest(calls[call].Req, calls[call].Mode)
+	assert.NoError(suite.T(), err)
+
+	return rec.Args
+}
+
+func (suite *UndoTestSuite) TestMigrateRecordsJournal() {
+	for range 6 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+	}
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 6)
+	assert.Equal(suite.T(), []any{DefaultUndoSpace, "migration-1", []any{"users"}}, suite.args(2))
+	assert.Equal(suite.T(), []any{"migration-1"}, suite.args(4))
+}
+
+func (suite *UndoTestSuite) TestMigrateRecordsJournalInMigrationWriteMode() {
+	for range 6 {
+		suite.doer.AddResponseRaw([][]interface{}{})
+	}
+
+	writeMode := pool.ModePreferRW
+	suite.testable.migrations[0].WriteMode = &writeMode
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 6)
+	assert.Equal(suite.T(), pool.ModePreferRW, calls[2].Mode)
+	assert.Equal(suite.T(), pool.ModePreferRW, calls[4].Mode)
+}
+
+func (suite *UndoTestSuite) TestMigrateStopsJournalOnError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{true})
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+	suite.doer.AddResponseError(fmt.Errorf("connection lost"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), "migration \"migration-1\" error: user migrate: eval lua: tarantool error\n"+
+		"stop undo journal: connection lost", err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 5)
+}
+
+func (suite *UndoTestSuite) TestMigrateStartJournalError() {
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseError(fmt.Errorf("space \"users\" does not exist"))
+
+	err := suite.testable.Migrate(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-1" error: start undo journal: space "users" does not exist`,
+		err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 3)
+}
+
+func (suite *UndoTestSuite) TestRollbackLastRestoresJournal() {
+	body := newMigrationTupleStubResponseBody()
+	body[0][0] = "migration-1"
+	suite.doer.AddResponseRaw(body)
+	suite.doer.AddResponseRaw([]interface{}{3})
+	suite.doer.AddResponseRaw([][]interface{}{})
+	suite.doer.AddResponseRaw([]interface{}{1})
+
+	err := suite.testable.RollbackLast(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	calls := suite.mock.DoCalls()
+	assert.Len(suite.T(), calls, 4)
+	assert.Equal(suite.T(), []any{DefaultUndoSpace, "migration-1"}, suite.args(1))
+
+	rec, err := describeRequest(calls[2].Req, calls[2].Mode)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "IPROTO_DELETE", rec.Type)
+	assert.Equal(suite.T(), []any{DefaultUndoSpace, "migration-1"}, suite.args(3))
+}
+
+func (suite *UndoTestSuite) TestRollbackLastRefusesConfirmedJournal() {
+	body := newMigrationTupleStubResponseBody()
+	body[0][0] = "migration-1"
+	suite.doer.AddResponseRaw(body)
+	suite.doer.AddResponseRaw([]interface{}{-1})
+
+	err := suite.testable.RollbackLast(suite.ctx)
+	assert.ErrorIs(suite.T(), err, ErrUndoJournalNotFound)
+	assert.Equal(suite.T(), `migration "migration-1" error: user rollback: undo: undo journal not found`, err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 2)
+}
+
+func (suite *UndoTestSuite) TestRollbackLastRestoreError() {
+	body := newMigrationTupleStubResponseBody()
+	body[0][0] = "migration-1"
+	suite.doer.AddResponseRaw(body)
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.RollbackLast(suite.ctx)
+	assert.Equal(suite.T(), `migration "migration-1" error: user rollback: undo: tarantool error`, err.Error())
+	assert.Len(suite.T(), suite.mock.DoCalls(), 2)
+}
+
+func (suite *UndoTestSuite) TestUndoRollbackOutsideMigrator() {
+	err := NewUndoRollbackFunction()(suite.ctx, suite.mock, DefaultOptions)
+	assert.ErrorIs(suite.T(), err, ErrMissingID)
+	assert.Empty(suite.T(), suite.mock.DoCalls())
+}
+
+func (suite *UndoTestSuite) TestConfirm() {
+	suite.doer.AddResponseRaw([]interface{}{2})
+	suite.doer.AddResponseRaw([]interface{}{0})
+
+	err := suite.testable.Confirm(suite.ctx, "migration-1", "migration-2")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.mock.DoCalls(), 2)
+	assert.Equal(suite.T(), []any{DefaultUndoSpace, "migration-2"}, suite.args(1))
+}
+
+func (suite *UndoTestSuite) TestConfirmError() {
+	suite.doer.AddResponseError(fmt.Errorf("tarantool error"))
+
+	err := suite.testable.Confirm(suite.ctx, "migration-1")
+	assert.Equal(suite.T(), `migration "migration-1" error: clear undo journal: tarantool error`, err.Error())
+}
+
+func TestUndoTestSuite(t *testing.T) {
+	suite.Run(t, new(UndoTestSuite))
+}